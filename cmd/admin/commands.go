@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/services"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+var errUsage = errors.New("usage")
+
+// adminCLI runs admin commands against any ServiceInterface.
+type adminCLI struct {
+	service services.ServiceInterface
+	in      io.Reader
+	out     io.Writer
+	errOut  io.Writer
+	json    bool
+}
+
+func (cli *adminCLI) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "users":
+		return cli.users(ctx, args[1:])
+	case "tweets":
+		return cli.tweets(ctx, args[1:])
+	case "follows":
+		return cli.follows(ctx, args[1:])
+	case "stats":
+		return cli.stats(ctx, args[1:])
+	case "import":
+		return cli.importData(ctx, args[1:])
+	case "export":
+		return cli.exportData(ctx, args[1:])
+	}
+	return errUsage
+}
+
+// userView leaves out the password.
+type userView struct {
+	ID            uint       `json:"id"`
+	Name          string     `json:"name"`
+	CreatedAt     time.Time  `json:"createdat"`
+	DeactivatedAt *time.Time `json:"deactivatedat,omitempty"`
+	DisabledAt    *time.Time `json:"disabledat,omitempty"`
+}
+
+func newUserView(user models.User) userView {
+	return userView{
+		ID:            user.ID,
+		Name:          user.Name,
+		CreatedAt:     user.CreatedAt,
+		DeactivatedAt: user.DeactivatedAt,
+		DisabledAt:    user.DisabledAt,
+	}
+}
+
+func (cli *adminCLI) users(ctx context.Context, args []string) error {
+	if len(args) == 1 && args[0] == "list" {
+		users, err := cli.service.ListUsers(ctx)
+		if err != nil {
+			return err
+		}
+		views := make([]userView, 0, len(*users))
+		rows := make([][]string, 0, len(*users))
+		for _, user := range *users {
+			view := newUserView(user)
+			views = append(views, view)
+			rows = append(rows, []string{strconv.Itoa(int(view.ID)), view.Name, formatTime(&view.CreatedAt), formatTime(view.DeactivatedAt), formatTime(view.DisabledAt)})
+		}
+		return cli.print(views, []string{"ID", "NAME", "CREATED", "DEACTIVATED", "DISABLED"}, rows)
+	}
+	if len(args) != 2 {
+		return errUsage
+	}
+	name := args[1]
+	switch args[0] {
+	case "create":
+		password, err := cli.readPassword()
+		if err != nil {
+			return err
+		}
+		user := models.User{Name: name, Password: password}
+		err = cli.service.AddUser(ctx, &user)
+		if err != nil {
+			return err
+		}
+		view := newUserView(user)
+		return cli.print(view, []string{"ID", "NAME"}, [][]string{{strconv.Itoa(int(view.ID)), view.Name}})
+	case "disable":
+		return cli.service.DisableUser(ctx, name)
+	case "enable":
+		return cli.service.EnableUser(ctx, name)
+	case "reset-password":
+		password, err := cli.readPassword()
+		if err != nil {
+			return err
+		}
+		return cli.service.ResetPassword(ctx, name, password)
+	}
+	return errUsage
+}
+
+func (cli *adminCLI) tweets(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	switch args[0] {
+	case "list":
+		tweets, err := cli.service.GetTweetsOfUser(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(*tweets))
+		for _, tweet := range *tweets {
+			rows = append(rows, []string{strconv.Itoa(int(tweet.ID)), formatTime(&tweet.CreatedAt), tweet.Content})
+		}
+		return cli.print(tweets, []string{"ID", "CREATED", "CONTENT"}, rows)
+	case "delete":
+		tweetid, err := strconv.Atoi(args[1])
+		if err != nil {
+			return errUsage
+		}
+		return cli.service.DeleteTweet(ctx, tweetid)
+	case "delete-all":
+		tweets, err := cli.service.GetTweetsOfUser(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		for _, tweet := range *tweets {
+			err = cli.service.DeleteTweet(ctx, int(tweet.ID))
+			if err != nil {
+				return err
+			}
+		}
+		return cli.print(map[string]int{"deleted": len(*tweets)}, []string{"DELETED"}, [][]string{{strconv.Itoa(len(*tweets))}})
+	}
+	return errUsage
+}
+
+func (cli *adminCLI) follows(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "list":
+		if len(args) != 2 {
+			return errUsage
+		}
+		followees, err := cli.service.GetFolloweesOfUser(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(*followees))
+		for _, follow := range *followees {
+			rows = append(rows, []string{follow.SourceUser, follow.TargetUser, formatTime(&follow.CreatedAt)})
+		}
+		return cli.print(followees, []string{"SOURCE", "TARGET", "SINCE"}, rows)
+	case "repair":
+		flags := flag.NewFlagSet("follows repair", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+		dryRun := flags.Bool("dry-run", false, "only count the self-follows")
+		if flags.Parse(args[1:]) != nil || flags.NArg() != 0 {
+			return errUsage
+		}
+		repair, err := cli.service.RepairFollows(ctx, *dryRun)
+		if err != nil {
+			return err
+		}
+		rows := [][]string{
+			{"selffollows", strconv.FormatInt(repair.SelfFollows, 10)},
+			{"dryrun", strconv.FormatBool(repair.DryRun)},
+		}
+		return cli.print(repair, []string{"PROBLEM", "COUNT"}, rows)
+	}
+	return errUsage
+}
+
+func (cli *adminCLI) stats(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	stats, err := cli.service.GetStats(ctx)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{
+		{"users", strconv.FormatInt(stats.Users, 10)},
+		{"deactivated users", strconv.FormatInt(stats.DeactivatedUsers, 10)},
+		{"disabled users", strconv.FormatInt(stats.DisabledUsers, 10)},
+		{"tweets", strconv.FormatInt(stats.Tweets, 10)},
+		{"follows", strconv.FormatInt(stats.Follows, 10)},
+		{"media", strconv.FormatInt(stats.Media, 10)},
+	}
+	return cli.print(stats, []string{"COUNT", "VALUE"}, rows)
+}
+
+func transferFlags(name string) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags, flags.String("format", "ndjson", "ndjson or csv")
+}
+
+func (cli *adminCLI) importData(ctx context.Context, args []string) error {
+	flags, format := transferFlags("import")
+	if flags.Parse(args) != nil {
+		return errUsage
+	}
+	args = flags.Args()
+	if len(args) < 1 || len(args) > 2 {
+		return errUsage
+	}
+	in := cli.in
+	if len(args) == 2 {
+		file, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	report, err := cli.service.Import(ctx, args[0], *format, in)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{{strconv.Itoa(report.Imported), strconv.Itoa(report.Failed)}}
+	if !cli.json {
+		for _, importErr := range report.Errors {
+			fmt.Fprintf(cli.errOut, "line %d: %s\n", importErr.Line, importErr.Error)
+		}
+	}
+	return cli.print(report, []string{"IMPORTED", "FAILED"}, rows)
+}
+
+func (cli *adminCLI) exportData(ctx context.Context, args []string) error {
+	flags, format := transferFlags("export")
+	passwords := flags.Bool("passwords", false, "export the passwords of users, in plain text")
+	if flags.Parse(args) != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+	return cli.service.Export(ctx, flags.Arg(0), *format, *passwords, cli.out)
+}
+
+// readPassword reads the first line of stdin, so that passwords do not show
+// up in the shell history or the process list.
+func (cli *adminCLI) readPassword() (string, error) {
+	scanner := bufio.NewScanner(cli.in)
+	if !scanner.Scan() {
+		if scanner.Err() != nil {
+			return "", scanner.Err()
+		}
+		return "", errors.New("no password on stdin")
+	}
+	return scanner.Text(), nil
+}
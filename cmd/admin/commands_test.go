@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	for _, args := range [][]string{{}, {"unknown"}, {"users"}, {"tweets", "delete", "x"}, {"follows", "repair", "-x"}} {
+		mockService := services.NewMockServiceInterface(gomock.NewController(t))
+		cli := &adminCLI{service: mockService, out: &bytes.Buffer{}}
+
+		err := cli.run(context.Background(), args)
+
+		assert.ErrorIs(t, err, errUsage, args)
+	}
+}
+
+func TestCreateUserReadsPassword(t *testing.T) {
+	mockService := services.NewMockServiceInterface(gomock.NewController(t))
+	mockService.
+		EXPECT().
+		AddUser(gomock.Any(), &models.User{Name: "abc", Password: "secret"}).
+		Return(nil).
+		Times(1)
+	out := &bytes.Buffer{}
+	cli := &adminCLI{service: mockService, in: strings.NewReader("secret\n"), out: out}
+
+	err := cli.run(context.Background(), []string{"users", "create", "abc"})
+
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "abc")
+	assert.NotContains(t, out.String(), "secret")
+}
+
+func TestExportPasswords(t *testing.T) {
+	for _, passwords := range []bool{false, true} {
+		mockService := services.NewMockServiceInterface(gomock.NewController(t))
+		mockService.EXPECT().Export(gomock.Any(), "users", "csv", passwords, gomock.Any()).Return(nil).Times(1)
+		cli := &adminCLI{service: mockService, out: &bytes.Buffer{}}
+		args := []string{"export", "-format", "csv", "users"}
+		if passwords {
+			args = []string{"export", "-format", "csv", "-passwords", "users"}
+		}
+
+		err := cli.run(context.Background(), args)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestDeleteAllTweets(t *testing.T) {
+	type testCase struct {
+		name              string
+		returnedError     error
+		expectedDeletes   int
+		expectedError     bool
+		expectedOutputted string
+	}
+	testCases := []testCase{{name: "error",
+		returnedError:   errors.New("some error"),
+		expectedDeletes: 1,
+		expectedError:   true},
+		{name: "success",
+			expectedDeletes:   2,
+			expectedOutputted: "\"deleted\": 2"}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			tweets := []models.Tweet{{Content: "a"}, {Content: "b"}}
+			tweets[0].ID = 1
+			tweets[1].ID = 2
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.EXPECT().GetTweetsOfUser(gomock.Any(), "abc").Return(&tweets, nil).Times(1)
+			mockService.
+				EXPECT().
+				DeleteTweet(gomock.Any(), gomock.Any()).
+				Return(test.returnedError).
+				Times(test.expectedDeletes)
+			out := &bytes.Buffer{}
+			cli := &adminCLI{service: mockService, out: out, json: true}
+
+			err := cli.run(context.Background(), []string{"tweets", "delete-all", "abc"})
+
+			assert.Equal(t, test.expectedError, err != nil)
+			assert.Contains(t, out.String(), test.expectedOutputted)
+		})
+	}
+}
+
+func TestRepairFollowsDryRun(t *testing.T) {
+	mockService := services.NewMockServiceInterface(gomock.NewController(t))
+	mockService.
+		EXPECT().
+		RepairFollows(gomock.Any(), true).
+		Return(&models.FollowRepair{SelfFollows: 3, DryRun: true}, nil).
+		Times(1)
+	out := &bytes.Buffer{}
+	cli := &adminCLI{service: mockService, out: out}
+
+	err := cli.run(context.Background(), []string{"follows", "repair", "-dry-run"})
+
+	assert.Nil(t, err)
+	assert.Regexp(t, `selffollows\s+3`, out.String())
+}
+
+func TestListUsersShowsDeactivated(t *testing.T) {
+	deactivatedAt := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	mockService := services.NewMockServiceInterface(gomock.NewController(t))
+	mockService.
+		EXPECT().
+		ListUsers(gomock.Any()).
+		Return(&[]models.User{{Name: "abc"}, {Name: "def", DeactivatedAt: &deactivatedAt}}, nil).
+		Times(1)
+	out := &bytes.Buffer{}
+	cli := &adminCLI{service: mockService, out: out}
+
+	err := cli.run(context.Background(), []string{"users", "list"})
+
+	assert.Nil(t, err)
+	assert.Regexp(t, `def\s+\S+\s+2026-03-04T05:06:07Z`, out.String())
+}
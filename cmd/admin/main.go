@@ -0,0 +1,89 @@
+// Command admin manages users, tweets and follows without going through the
+// API or touching the database by hand.
+//
+//	admin [-config file] [-database.dsn dsn] [-o table|json] command [arguments]
+//
+// The database is configured like the server, see package config.
+//
+// Commands:
+//
+//	users list                   also accounts pending deletion
+//	users create name            the password is read from stdin
+//	users disable name
+//	users enable name
+//	users reset-password name    the password is read from stdin
+//	tweets list name
+//	tweets delete tweetid
+//	tweets delete-all name
+//	follows list name
+//	follows repair [-dry-run]
+//	stats
+//	import [-format ndjson|csv] users|tweets|follows [file]
+//	export [-format ndjson|csv] [-passwords] users|tweets|follows
+//
+// Import reads stdin unless a file is given, export writes to stdout. Users
+// are exported without their passwords unless -passwords is given, and
+// cannot be imported again without them.
+package main
+
+import (
+	"context"
+	"errors"
+	"example/layered-architecture/config"
+	"example/layered-architecture/logging"
+	"example/layered-architecture/repositories"
+	"example/layered-architecture/services"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	output := flag.String("o", "table", "output format, table or json")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: admin [-config file] [-database.dsn dsn] [-o table|json] command [arguments]")
+		flag.PrintDefaults()
+	}
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *output != "table" && *output != "json" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	//logs go to a terminal, apart from the output of the command
+	slog.SetDefault(logging.New(os.Stderr, cfg.Log.Level, "text"))
+
+	//interrupting the command also cancels its queries
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	repository, err := repositories.NewMySqlRepository(ctx, cfg.Database.DSN, cfg.RepositoryOptions())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer repository.Close()
+	pending, err := repository.PendingMigrations(ctx)
+	if err != nil {
+		log.Fatal("cannot read schema version")
+	}
+	if pending > 0 {
+		log.Fatalf("database schema is %d migration(s) behind, run `migrate up` first", pending)
+	}
+	//no admin command touches media files
+	service := services.NewUserService(repository, nil)
+
+	cli := &adminCLI{service: service, in: os.Stdin, out: os.Stdout, errOut: os.Stderr, json: *output == "json"}
+	err = cli.run(ctx, flag.Args())
+	if errors.Is(err, errUsage) {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "admin:", err)
+		os.Exit(1)
+	}
+}
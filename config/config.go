@@ -0,0 +1,474 @@
+// Package config loads the settings of the server and the admin command.
+//
+// Settings come from, in increasing order of precedence: built-in defaults,
+// a YAML file given with -config or CONFIG_FILE, environment variables and
+// command line flags. Every setting has a key such as database.dsn, which is
+// its path in the file and the name of its flag.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"example/layered-architecture/repositories"
+	"example/layered-architecture/tracing"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server   Server   `yaml:"server"`
+	Database Database `yaml:"database"`
+	CORS     CORS     `yaml:"cors"`
+	Log      Log      `yaml:"log"`
+	Media    Media    `yaml:"media"`
+	Admin    Admin    `yaml:"admin"`
+	Features Features `yaml:"features"`
+	Tracing  Tracing  `yaml:"tracing"`
+}
+
+type Server struct {
+	Addr string `yaml:"addr"`
+	TLS  TLS    `yaml:"tls"`
+	// RequestTimeout is the deadline of requests to routes without an entry
+	// in RouteTimeouts, which is keyed by route path template. Routes in the
+	// file are added to the default ones, 0 takes the deadline off a route.
+	RequestTimeout time.Duration            `yaml:"requesttimeout"`
+	RouteTimeouts  map[string]time.Duration `yaml:"routetimeouts"`
+	// ReadTimeout and WriteTimeout bound reading a request and writing its
+	// response on routes without an entry in RouteTimeouts.
+	ReadTimeout  time.Duration `yaml:"readtimeout"`
+	WriteTimeout time.Duration `yaml:"writetimeout"`
+	IdleTimeout  time.Duration `yaml:"idletimeout"`
+	// ShutdownTimeout is how long in-flight requests and background work may
+	// take to finish on shutdown before they are cut off.
+	ShutdownTimeout time.Duration `yaml:"shutdowntimeout"`
+}
+
+// TLS is on when its files are set, it needs both of them.
+type TLS struct {
+	CertFile string `yaml:"certfile"`
+	KeyFile  string `yaml:"keyfile"`
+}
+
+func (t TLS) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != ""
+}
+
+type Database struct {
+	DSN             string        `yaml:"dsn"`
+	MaxOpenConns    int           `yaml:"maxopenconns"`
+	MaxIdleConns    int           `yaml:"maxidleconns"`
+	ConnMaxLifetime time.Duration `yaml:"connmaxlifetime"`
+	// ConnectRetry is how long connecting is retried on startup.
+	ConnectRetry time.Duration `yaml:"connectretry"`
+}
+
+// TracingOptions are the settings of the tracer provider.
+func (c *Config) TracingOptions() tracing.Options {
+	return tracing.Options{
+		Exporter:    c.Tracing.Exporter,
+		Endpoint:    c.Tracing.Endpoint,
+		Insecure:    c.Tracing.Insecure,
+		SampleRatio: c.Tracing.SampleRatio,
+		ServiceName: c.Tracing.ServiceName,
+	}
+}
+
+// RepositoryOptions are the pool and logging settings of the repository.
+func (c *Config) RepositoryOptions() repositories.Options {
+	return repositories.Options{
+		MaxOpenConns:    c.Database.MaxOpenConns,
+		MaxIdleConns:    c.Database.MaxIdleConns,
+		ConnMaxLifetime: c.Database.ConnMaxLifetime,
+		ConnectRetry:    c.Database.ConnectRetry,
+	}
+}
+
+type CORS struct {
+	AllowedOrigins []string `yaml:"allowedorigins"`
+}
+
+type Log struct {
+	Level string `yaml:"level"`
+	// Format is json or text.
+	Format string `yaml:"format"`
+}
+
+type Media struct {
+	Dir string `yaml:"dir"`
+}
+
+type Admin struct {
+	// Token guards the admin api, which stays off while it is empty.
+	Token string `yaml:"token"`
+}
+
+// Features switch the background workers on and off, for instance to run
+// them on a single instance only.
+type Features struct {
+	Scheduler    bool `yaml:"scheduler"`
+	Suggestions  bool `yaml:"suggestions"`
+	AccountPurge bool `yaml:"accountpurge"`
+	DataExports  bool `yaml:"dataexports"`
+}
+
+type Tracing struct {
+	// Exporter is none, stdout or otlp.
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the host:port of the OTLP/HTTP collector.
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sampleratio"`
+	ServiceName string  `yaml:"servicename"`
+}
+
+// LogLevels are the accepted values of log.level, debug also logs every
+// query.
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// LogFormats are the accepted values of log.format.
+var LogFormats = []string{"json", "text"}
+
+// Default returns the settings used when nothing else is configured.
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Addr:           ":8000",
+			RequestTimeout: 10 * time.Second,
+			//uploads and bulk transfers need more time than the rest of the api
+			RouteTimeouts: map[string]time.Duration{
+				"/api/media":               time.Minute,
+				"/api/exports/{token}":     10 * time.Minute,
+				"/api/admin/import/{kind}": 30 * time.Minute,
+				"/api/admin/export/{kind}": 30 * time.Minute,
+			},
+			ReadTimeout:     30 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     2 * time.Minute,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Database: Database{
+			DSN:             "root:@tcp(127.0.0.1:3306)/demodb?parseTime=true",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnectRetry:    time.Minute,
+		},
+		CORS:  CORS{AllowedOrigins: []string{"http://localhost:3000"}},
+		Log:   Log{Level: "info", Format: "json"},
+		Media: Media{Dir: "./media"},
+		Features: Features{
+			Scheduler:    true,
+			Suggestions:  true,
+			AccountPurge: true,
+			DataExports:  true,
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "layered-architecture",
+		},
+	}
+}
+
+// setting is a single value that can be set from the environment or a flag.
+// target points into the Config.
+type setting struct {
+	key    string
+	env    string
+	target interface{}
+	usage  string
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		{"server.addr", "LISTEN_ADDR", &c.Server.Addr, "address to listen on"},
+		{"server.tls.certfile", "TLS_CERT_FILE", &c.Server.TLS.CertFile, "TLS certificate file"},
+		{"server.tls.keyfile", "TLS_KEY_FILE", &c.Server.TLS.KeyFile, "TLS key file"},
+		{"server.requesttimeout", "REQUEST_TIMEOUT", &c.Server.RequestTimeout, "default request deadline"},
+		{"server.readtimeout", "READ_TIMEOUT", &c.Server.ReadTimeout, "deadline for reading a request"},
+		{"server.writetimeout", "WRITE_TIMEOUT", &c.Server.WriteTimeout, "deadline for writing a response"},
+		{"server.idletimeout", "IDLE_TIMEOUT", &c.Server.IdleTimeout, "how long idle keep-alive connections stay open"},
+		{"server.shutdowntimeout", "SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout, "how long shutting down waits for in-flight work"},
+		{"database.dsn", "DB_DSN", &c.Database.DSN, "MySQL data source name"},
+		{"database.maxopenconns", "DB_MAX_OPEN_CONNS", &c.Database.MaxOpenConns, "maximum open database connections"},
+		{"database.maxidleconns", "DB_MAX_IDLE_CONNS", &c.Database.MaxIdleConns, "maximum idle database connections"},
+		{"database.connmaxlifetime", "DB_CONN_MAX_LIFETIME", &c.Database.ConnMaxLifetime, "maximum lifetime of a database connection"},
+		{"database.connectretry", "DB_CONNECT_RETRY", &c.Database.ConnectRetry, "how long to retry connecting to the database on startup"},
+		{"cors.allowedorigins", "CORS_ALLOWED_ORIGINS", &c.CORS.AllowedOrigins, "comma separated origins allowed by CORS"},
+		{"log.level", "LOG_LEVEL", &c.Log.Level, "log level: " + strings.Join(LogLevels, ", ")},
+		{"log.format", "LOG_FORMAT", &c.Log.Format, "log format: " + strings.Join(LogFormats, ", ")},
+		{"media.dir", "MEDIA_DIR", &c.Media.Dir, "directory for uploaded media and exports"},
+		{"admin.token", "ADMIN_TOKEN", &c.Admin.Token, "bearer token of the admin api, empty turns it off"},
+		{"features.scheduler", "FEATURE_SCHEDULER", &c.Features.Scheduler, "publish scheduled drafts"},
+		{"features.suggestions", "FEATURE_SUGGESTIONS", &c.Features.Suggestions, "refresh follow suggestions"},
+		{"features.accountpurge", "FEATURE_ACCOUNT_PURGE", &c.Features.AccountPurge, "delete deactivated accounts"},
+		{"features.dataexports", "FEATURE_DATA_EXPORTS", &c.Features.DataExports, "build personal data exports"},
+		{"tracing.exporter", "TRACING_EXPORTER", &c.Tracing.Exporter, "trace exporter: " + strings.Join(tracing.Exporters, ", ")},
+		{"tracing.endpoint", "TRACING_ENDPOINT", &c.Tracing.Endpoint, "host:port of the OTLP/HTTP collector"},
+		{"tracing.insecure", "TRACING_INSECURE", &c.Tracing.Insecure, "send traces over plain HTTP"},
+		{"tracing.sampleratio", "TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio, "share of new traces that are recorded"},
+		{"tracing.servicename", "TRACING_SERVICE_NAME", &c.Tracing.ServiceName, "service name on the traces"},
+	}
+}
+
+// set parses value into the setting.
+func (s setting) set(value string) error {
+	switch target := s.target.(type) {
+	case *string:
+		*target = value
+	case *int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*target = n
+	case *bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+		*target = b
+	case *float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*target = f
+	case *time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration such as 30s or 5m", value)
+		}
+		*target = d
+	case *[]string:
+		*target = nil
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*target = append(*target, item)
+			}
+		}
+	}
+	return nil
+}
+
+// flagValue only touches the setting when the flag is given, so flags that
+// are left out do not undo the file and the environment.
+type flagValue struct {
+	setting setting
+	value   string
+}
+
+func (f *flagValue) String() string { return f.value }
+
+func (f *flagValue) Set(value string) error {
+	f.value = value
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	_, ok := f.setting.target.(*bool)
+	return ok
+}
+
+// Load adds a flag for every setting and -config to fs, parses args with it
+// and returns the validated configuration. The arguments left after the
+// flags are available from fs.Args.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	path := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML configuration file")
+	c := Default()
+	var flags []*flagValue
+	for _, s := range c.settings() {
+		f := &flagValue{setting: s}
+		fs.Var(f, s.key, s.usage+" (env "+s.env+")")
+		flags = append(flags, f)
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if *path != "" {
+		err = c.readFile(*path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var problems []string
+	for _, s := range c.settings() {
+		value, ok := os.LookupEnv(s.env)
+		if !ok {
+			continue
+		}
+		err = s.set(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", s.env, err))
+		}
+	}
+	fs.Visit(func(given *flag.Flag) {
+		for _, f := range flags {
+			if f.setting.key != given.Name {
+				continue
+			}
+			err := f.setting.set(f.value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("-%s: %v", given.Name, err))
+			}
+		}
+	})
+	if len(problems) > 0 {
+		return nil, &Error{Problems: problems}
+	}
+	return c, c.Validate()
+}
+
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	//a misspelt key would silently keep its default
+	decoder.KnownFields(true)
+	err = decoder.Decode(c)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+	return nil
+}
+
+// Error lists everything that is wrong with a configuration.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// Validate checks the configuration and reports all problems at once, each
+// naming the key of the setting.
+func (c *Config) Validate() error {
+	var problems []string
+	fail := func(key string, format string, args ...interface{}) {
+		problems = append(problems, key+": "+fmt.Sprintf(format, args...))
+	}
+
+	if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+		fail("server.addr", "%q is not host:port, use :8000 to listen on all interfaces", c.Server.Addr)
+	}
+	if c.Server.TLS.Enabled() {
+		for key, file := range map[string]string{"server.tls.certfile": c.Server.TLS.CertFile, "server.tls.keyfile": c.Server.TLS.KeyFile} {
+			if file == "" {
+				fail(key, "is required when TLS is on")
+			} else if _, err := os.Stat(file); err != nil {
+				fail(key, "cannot read %q", file)
+			}
+		}
+	}
+	if c.Server.RequestTimeout <= 0 {
+		fail("server.requesttimeout", "must be positive")
+	}
+	for key, timeout := range map[string]time.Duration{
+		"server.readtimeout":     c.Server.ReadTimeout,
+		"server.idletimeout":     c.Server.IdleTimeout,
+		"server.shutdowntimeout": c.Server.ShutdownTimeout,
+	} {
+		if timeout <= 0 {
+			fail(key, "must be positive")
+		}
+	}
+	//otherwise requests that time out could not write their error
+	if c.Server.WriteTimeout <= c.Server.RequestTimeout {
+		fail("server.writetimeout", "must be longer than server.requesttimeout (%s)", c.Server.RequestTimeout)
+	}
+	for route, timeout := range c.Server.RouteTimeouts {
+		if !strings.HasPrefix(route, "/") {
+			fail("server.routetimeouts", "%q is not a route path template", route)
+		}
+		if timeout < 0 {
+			fail("server.routetimeouts", "%q must not be negative, 0 means no deadline", route)
+		}
+	}
+
+	dsn, err := mysql.ParseDSN(c.Database.DSN)
+	switch {
+	case err != nil:
+		fail("database.dsn", "%v", err)
+	case !dsn.ParseTime:
+		fail("database.dsn", "needs parseTime=true")
+	}
+	if c.Database.MaxOpenConns < 1 {
+		fail("database.maxopenconns", "must be at least 1")
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		fail("database.maxidleconns", "must be between 0 and database.maxopenconns (%d)", c.Database.MaxOpenConns)
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		fail("database.connmaxlifetime", "must not be negative, 0 means forever")
+	}
+	if c.Database.ConnectRetry < 0 {
+		fail("database.connectretry", "must not be negative, 0 means no retries")
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+			fail("cors.allowedorigins", "%q is not an origin such as https://example.com", origin)
+		}
+	}
+	if !contains(LogLevels, c.Log.Level) {
+		fail("log.level", "%q is not one of %s", c.Log.Level, strings.Join(LogLevels, ", "))
+	}
+	if !contains(LogFormats, c.Log.Format) {
+		fail("log.format", "%q is not one of %s", c.Log.Format, strings.Join(LogFormats, ", "))
+	}
+	if c.Media.Dir == "" {
+		fail("media.dir", "is required")
+	}
+	if !contains(tracing.Exporters, c.Tracing.Exporter) {
+		fail("tracing.exporter", "%q is not one of %s", c.Tracing.Exporter, strings.Join(tracing.Exporters, ", "))
+	}
+	if c.Tracing.Exporter == "otlp" {
+		if _, _, err := net.SplitHostPort(c.Tracing.Endpoint); err != nil {
+			fail("tracing.endpoint", "%q is not host:port", c.Tracing.Endpoint)
+		}
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		fail("tracing.sampleratio", "must be between 0 and 1")
+	}
+	if c.Tracing.ServiceName == "" {
+		fail("tracing.servicename", "is required")
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return &Error{Problems: problems}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func load(args ...string) (*Config, error) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return Load(fs, args)
+}
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDefault(t *testing.T) {
+	assert.NoError(t, Default().Validate())
+}
+
+func TestLoad(t *testing.T) {
+	path := writeFile(t, `
+server:
+  addr: ":9000"
+  requesttimeout: 5s
+  routetimeouts:
+    /api/media: 2m
+database:
+  maxopenconns: 50
+  maxidleconns: 10
+cors:
+  allowedorigins: [https://a.example.com]
+log:
+  level: warn
+features:
+  scheduler: false
+`)
+	t.Setenv("DB_MAX_OPEN_CONNS", "40")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://b.example.com, https://c.example.com")
+	t.Setenv("LOG_LEVEL", "error")
+
+	cfg, err := load("-config", path, "-log.level", "debug", "-features.suggestions=false", "migrate", "up")
+
+	assert.NoError(t, err)
+	//from the file
+	assert.Equal(t, ":9000", cfg.Server.Addr)
+	assert.Equal(t, 5*time.Second, cfg.Server.RequestTimeout)
+	assert.Equal(t, 2*time.Minute, cfg.Server.RouteTimeouts["/api/media"])
+	assert.Equal(t, 10*time.Minute, cfg.Server.RouteTimeouts["/api/exports/{token}"])
+	assert.Equal(t, 10, cfg.Database.MaxIdleConns)
+	assert.False(t, cfg.Features.Scheduler)
+	//the environment wins over the file
+	assert.Equal(t, 40, cfg.Database.MaxOpenConns)
+	assert.Equal(t, []string{"https://b.example.com", "https://c.example.com"}, cfg.CORS.AllowedOrigins)
+	//flags win over both
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.False(t, cfg.Features.Suggestions)
+	//untouched settings keep their defaults
+	assert.Equal(t, Default().Database.DSN, cfg.Database.DSN)
+	assert.True(t, cfg.Features.DataExports)
+}
+
+func TestLoadErrors(t *testing.T) {
+	type testCase struct {
+		name          string
+		file          string
+		env           map[string]string
+		args          []string
+		expectedError string
+	}
+	testCases := []testCase{{name: "unknown key",
+		file:          "database:\n  dns: x\n",
+		expectedError: "field dns not found"},
+		{name: "bad env",
+			env:           map[string]string{"DB_MAX_OPEN_CONNS": "many"},
+			expectedError: "DB_MAX_OPEN_CONNS: \"many\" is not a number"},
+		{name: "bad flag",
+			args:          []string{"-server.requesttimeout", "10"},
+			expectedError: "-server.requesttimeout: \"10\" is not a duration such as 30s or 5m"},
+		{name: "missing file",
+			args:          []string{"-config", "does-not-exist.yaml"},
+			expectedError: "config: open does-not-exist.yaml"}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			args := test.args
+			if test.file != "" {
+				args = append([]string{"-config", writeFile(t, test.file)}, args...)
+			}
+
+			_, err := load(args...)
+
+			assert.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.Server.Addr = "8000"
+	cfg.Server.TLS.CertFile = "cert.pem"
+	cfg.Server.WriteTimeout = cfg.Server.RequestTimeout
+	cfg.Database.DSN = "root:@tcp(127.0.0.1:3306)/demodb"
+	cfg.Database.MaxIdleConns = 30
+	cfg.CORS.AllowedOrigins = []string{"*", "localhost:3000"}
+	cfg.Log.Level = "verbose"
+	cfg.Tracing.Exporter = "jaeger"
+	cfg.Tracing.SampleRatio = 1.5
+
+	err := cfg.Validate()
+
+	var configErr *Error
+	assert.ErrorAs(t, err, &configErr)
+	assert.Equal(t, []string{
+		`cors.allowedorigins: "localhost:3000" is not an origin such as https://example.com`,
+		"database.dsn: needs parseTime=true",
+		"database.maxidleconns: must be between 0 and database.maxopenconns (25)",
+		`log.level: "verbose" is not one of debug, info, warn, error`,
+		`server.addr: "8000" is not host:port, use :8000 to listen on all interfaces`,
+		`server.tls.certfile: cannot read "cert.pem"`,
+		"server.tls.keyfile: is required when TLS is on",
+		"server.writetimeout: must be longer than server.requesttimeout (10s)",
+		`tracing.exporter: "jaeger" is not one of none, stdout, otlp`,
+		"tracing.sampleratio: must be between 0 and 1",
+	}, configErr.Problems)
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"example/layered-architecture/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) AddBookmark(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["tweetid"])
+	if err != nil {
+		writeError(w, r, invalidParam("tweetid"))
+		return
+	}
+	//the body is optional and only carries the folder
+	var body struct {
+		FolderID *uint `json:"folderid"`
+	}
+	err = decodeBody(w, r, &body)
+	if err != nil && err != errNoBody {
+		writeError(w, r, err)
+		return
+	}
+	bookmark := models.Bookmark{UserName: username, TweetID: uint(val), FolderID: body.FolderID}
+	err = h.service.AddBookmark(r.Context(), &bookmark)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(&bookmark)
+}
+
+func (h *Handler) DeleteBookmark(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["tweetid"])
+	if err != nil {
+		writeError(w, r, invalidParam("tweetid"))
+		return
+	}
+	err = h.service.DeleteBookmark(r.Context(), username, val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("deleted bookmark")
+}
+
+func (h *Handler) GetBookmarks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	query := r.URL.Query()
+	var folderid *uint
+	if query.Get("folder") != "" {
+		val, err := strconv.ParseUint(query.Get("folder"), 10, 64)
+		if err != nil {
+			writeError(w, r, invalidParam("folder"))
+			return
+		}
+		id := uint(val)
+		folderid = &id
+	}
+	//a missing or malformed limit falls back to the default page size
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	page, err := h.service.GetBookmarks(r.Context(), username, folderid, query.Get("cursor"), limit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *Handler) GetBookmarkFolders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	folders, err := h.service.GetBookmarkFolders(r.Context(), username)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(folders)
+}
+
+func (h *Handler) AddBookmarkFolder(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	var folder models.BookmarkFolder
+	err := decodeBody(w, r, &folder)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	folder.UserName = username
+	err = h.service.AddBookmarkFolder(r.Context(), &folder)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(&folder)
+}
+
+func (h *Handler) DeleteBookmarkFolder(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["folderid"])
+	if err != nil {
+		writeError(w, r, invalidParam("folderid"))
+		return
+	}
+	err = h.service.DeleteBookmarkFolder(r.Context(), username, val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("deleted folder")
+}
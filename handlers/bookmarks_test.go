@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"example/layered-architecture/models"
+	"example/layered-architecture/services"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBookmark(t *testing.T) {
+	type testCase struct {
+		name                     string
+		body                     string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "error",
+		expectedStatusCode:       http.StatusBadRequest,
+		expectedCalls:            1,
+		returnedErrorFromService: services.ValidationError("some error", nil)},
+		{name: "success",
+			expectedStatusCode:       http.StatusCreated,
+			expectedCalls:            1,
+			returnedErrorFromService: nil},
+		{name: "tweet in body",
+			body:               `{"tweet":{"content":"forged","media":[{"ID":7}]}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCalls:      0}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/api/tweet/", strings.NewReader(test.body))
+			req.Header.Set("X-Username", "abc")
+			req = mux.SetURLVars(req, map[string]string{"tweetid": "4"})
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				AddBookmark(gomock.Any(), &models.Bookmark{UserName: "abc", TweetID: 4}).
+				Return(test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+
+			mh.AddBookmark(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
+
+func TestGetBookmarks(t *testing.T) {
+	type testCase struct {
+		name                     string
+		username                 string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "no user",
+		username:           "",
+		expectedStatusCode: http.StatusUnauthorized,
+		expectedCalls:      0},
+		{name: "error",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusBadRequest,
+			expectedCalls:            1,
+			returnedErrorFromService: services.ValidationError("some error", nil)},
+		{name: "success",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusOK,
+			expectedCalls:            1,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/api/bookmarks?cursor=12&limit=5", http.NoBody)
+			req.Header.Set("X-Username", test.username)
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				GetBookmarks(gomock.Any(), test.username, nil, "12", 5).
+				Return(&models.BookmarkPage{}, test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+
+			mh.GetBookmarks(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
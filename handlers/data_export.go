@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"example/layered-architecture/models"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) RequestDataExport(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	export, err := h.service.RequestDataExport(r.Context(), username)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	//the archive is built in the background, poll GetDataExport for it
+	if export.Status != models.DataExportReady {
+		w.WriteHeader(http.StatusAccepted)
+	}
+	json.NewEncoder(w).Encode(export)
+}
+
+func (h *Handler) GetDataExport(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	export, err := h.service.GetDataExport(r.Context(), username)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(export)
+}
+
+// DownloadDataExport needs no X-Username, the token in the link is enough.
+func (h *Handler) DownloadDataExport(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	archive, err := h.service.OpenDataExport(r.Context(), params["token"])
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	defer archive.Close()
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="data-export.zip"`)
+	io.Copy(w, archive)
+}
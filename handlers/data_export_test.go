@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"example/layered-architecture/models"
+	"example/layered-architecture/services"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestDataExport(t *testing.T) {
+	type testCase struct {
+		name                     string
+		username                 string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedStatus           string
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "no user",
+		username:           "",
+		expectedStatusCode: http.StatusUnauthorized,
+		expectedCalls:      0},
+		{name: "earlier export ready",
+			username:           "abc",
+			expectedStatusCode: http.StatusOK,
+			expectedCalls:      1,
+			returnedStatus:     models.DataExportReady},
+		{name: "error",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusBadRequest,
+			expectedCalls:            1,
+			returnedErrorFromService: services.ValidationError("some error", nil)},
+		{name: "success",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusAccepted,
+			expectedCalls:            1,
+			returnedStatus:           models.DataExportPending,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/api/user/me/export", http.NoBody)
+			req.Header.Set("X-Username", test.username)
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				RequestDataExport(gomock.Any(), test.username).
+				Return(&models.DataExport{Status: test.returnedStatus}, test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+			mh.RequestDataExport(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
+
+func TestDownloadDataExport(t *testing.T) {
+	type testCase struct {
+		name                     string
+		expectedStatusCode       int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "unknown or expired token",
+		expectedStatusCode:       http.StatusNotFound,
+		returnedErrorFromService: services.NotFoundError("export expired")},
+		{name: "success",
+			expectedStatusCode:       http.StatusOK,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/api/exports/token", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"token": "token"})
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				OpenDataExport(gomock.Any(), "token").
+				Return(io.NopCloser(strings.NewReader("zip")), test.returnedErrorFromService).
+				Times(1)
+
+			mh := NewHandler(mockService)
+			mh.DownloadDataExport(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"example/layered-architecture/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetDrafts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	drafts, err := h.service.GetDraftsOfUser(r.Context(), username)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(drafts)
+}
+
+func (h *Handler) AddDraft(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	var draft models.Draft
+	err := decodeBody(w, r, &draft)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	draft.UserName = username
+	err = h.service.AddDraft(r.Context(), &draft)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(&draft)
+}
+
+func (h *Handler) GetDraft(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["draftid"])
+	if err != nil {
+		writeError(w, r, invalidParam("draftid"))
+		return
+	}
+	draft, err := h.service.GetDraft(r.Context(), currentUser(r), val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(draft)
+}
+
+func (h *Handler) UpdateDraft(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["draftid"])
+	if err != nil {
+		writeError(w, r, invalidParam("draftid"))
+		return
+	}
+	var draft models.Draft
+	err = decodeBody(w, r, &draft)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	draft.ID = uint(val)
+	err = h.service.UpdateDraft(r.Context(), currentUser(r), &draft)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(&draft)
+}
+
+func (h *Handler) DeleteDraft(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["draftid"])
+	if err != nil {
+		writeError(w, r, invalidParam("draftid"))
+		return
+	}
+	err = h.service.DeleteDraft(r.Context(), currentUser(r), val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("deleted draft")
+}
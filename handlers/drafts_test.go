@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"example/layered-architecture/models"
+	"example/layered-architecture/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddDraft(t *testing.T) {
+	type testCase struct {
+		name                     string
+		username                 string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "no user",
+		username:           "",
+		expectedStatusCode: http.StatusUnauthorized,
+		expectedCalls:      0},
+		{name: "error",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusBadRequest,
+			expectedCalls:            1,
+			returnedErrorFromService: services.ValidationError("some error", nil)},
+		{name: "success",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusCreated,
+			expectedCalls:            1,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			body, _ := json.Marshal(&models.Draft{Content: "later"})
+			req, _ := http.NewRequest(http.MethodPost, "/api/drafts", bytes.NewBuffer(body))
+			req.Header.Set("X-Username", test.username)
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				AddDraft(gomock.Any(), &models.Draft{UserName: test.username, Content: "later"}).
+				Return(test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+
+			mh.AddDraft(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
+
+func TestGetDraft(t *testing.T) {
+	type testCase struct {
+		name                     string
+		expectedStatusCode       int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "not found",
+		expectedStatusCode:       http.StatusNotFound,
+		returnedErrorFromService: services.NotFoundError("draft not found")},
+		{name: "success",
+			expectedStatusCode:       http.StatusOK,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/api/drafts/", http.NoBody)
+			req.Header.Set("X-Username", "abc")
+			req = mux.SetURLVars(req, map[string]string{"draftid": "3"})
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				GetDraft(gomock.Any(), "abc", 3).
+				Return(&models.Draft{}, test.returnedErrorFromService).
+				Times(1)
+
+			mh := NewHandler(mockService)
+
+			mh.GetDraft(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
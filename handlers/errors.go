@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"example/layered-architecture/logging"
+	"example/layered-architecture/services"
+	"log/slog"
+	"net/http"
+)
+
+const (
+	codeInternal = "internal"
+	codeTimeout  = "timeout"
+	codeCanceled = "canceled"
+)
+
+// statusClientClosedRequest is the status nginx logs for requests the client
+// gave up on. Nobody reads the response, it only shows up in logs.
+const statusClientClosedRequest = 499
+
+// errorResponse is the body of every error response. The request id lets
+// support find the logs of the request.
+type errorResponse struct {
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	RequestID string            `json:"requestid,omitempty"`
+}
+
+var errorStatus = map[string]int{
+	services.CodeNotFound:     http.StatusNotFound,
+	services.CodeConflict:     http.StatusConflict,
+	services.CodeValidation:   http.StatusBadRequest,
+	services.CodeUnauthorized: http.StatusUnauthorized,
+	services.CodeForbidden:    http.StatusForbidden,
+}
+
+// errNoUser is returned by endpoints acting on behalf of the current user
+// when the request does not say who that is.
+var errNoUser = services.UnauthorizedError("the X-Username header is required")
+
+// errNoBody is returned by decodeBody for an empty request body.
+var errNoBody = services.ValidationError("request body is required", nil)
+
+// writeError answers with the status that belongs to err and a JSON body
+// describing it. Requests that ran out of time or were cancelled by the client
+// say so. Any other error that is not a service error is internal: it is
+// logged and its details are not shown to the client.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	response := errorResponse{Code: codeInternal, Message: "internal error"}
+	var serviceErr *services.Error
+	switch {
+	case errors.As(err, &serviceErr):
+		status = errorStatus[serviceErr.Code]
+		response = errorResponse{Code: serviceErr.Code, Message: serviceErr.Message, Fields: serviceErr.Fields}
+	case errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusServiceUnavailable
+		response = errorResponse{Code: codeTimeout, Message: "request timed out"}
+	case errors.Is(err, context.Canceled):
+		status = statusClientClosedRequest
+		response = errorResponse{Code: codeCanceled, Message: "request cancelled"}
+	default:
+		slog.ErrorContext(r.Context(), "internal error", "error", err)
+	}
+	response.RequestID = logging.RequestID(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// invalidParam is the error for a path or query parameter that cannot be
+// parsed.
+func invalidParam(name string) error {
+	return services.ValidationError("invalid "+name, map[string]string{name: "is not valid"})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"example/layered-architecture/logging"
+	"example/layered-architecture/services"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteError(t *testing.T) {
+	type testCase struct {
+		name               string
+		err                error
+		expectedStatusCode int
+		expectedBody       errorResponse
+	}
+	testCases := []testCase{{name: "not found",
+		err:                services.NotFoundError("tweet not found"),
+		expectedStatusCode: http.StatusNotFound,
+		expectedBody:       errorResponse{Code: "not_found", Message: "tweet not found"}},
+		{name: "conflict",
+			err:                services.ConflictError("name taken"),
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       errorResponse{Code: "conflict", Message: "name taken"}},
+		{name: "validation",
+			err:                services.ValidationError("invalid user", map[string]string{"name": "is required"}),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       errorResponse{Code: "validation_failed", Message: "invalid user", Fields: map[string]string{"name": "is required"}}},
+		{name: "unauthorized",
+			err:                errNoUser,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       errorResponse{Code: "unauthorized", Message: "the X-Username header is required"}},
+		{name: "forbidden",
+			err:                services.ForbiddenError("not yours"),
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       errorResponse{Code: "forbidden", Message: "not yours"}},
+		{name: "timeout",
+			err:                fmt.Errorf("query: %w", context.DeadlineExceeded),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       errorResponse{Code: "timeout", Message: "request timed out"}},
+		{name: "canceled",
+			err:                context.Canceled,
+			expectedStatusCode: statusClientClosedRequest,
+			expectedBody:       errorResponse{Code: "canceled", Message: "request cancelled"}},
+		{name: "internal",
+			err:                errors.New("connection refused"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       errorResponse{Code: "internal", Message: "internal error"}}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(logging.WithRequestID(context.Background(), "req-1"), http.MethodGet, "/", http.NoBody)
+
+			writeError(res, req, test.err)
+
+			var body errorResponse
+			json.NewDecoder(res.Body).Decode(&body)
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+			assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+			//every error names its request
+			test.expectedBody.RequestID = "req-1"
+			assert.Equal(t, test.expectedBody, body)
+		})
+	}
+}
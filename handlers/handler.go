@@ -2,10 +2,16 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"example/layered-architecture/models"
 	"example/layered-architecture/services"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 )
@@ -18,25 +24,62 @@ func NewHandler(service services.ServiceInterface) *Handler {
 	return &Handler{service: service}
 }
 
+// currentUser returns the name of the user making the request. As everywhere
+// else in the api the client states who it is acting as, here in a header.
+func currentUser(r *http.Request) string {
+	return r.Header.Get("X-Username")
+}
+
+// maxBodySize limits the JSON request bodies. Media uploads have their own
+// limit.
+const maxBodySize = 64 << 10
+
+// decodeBody reads the JSON body of r into v. Bodies that are too large, are
+// not valid JSON or have fields v does not know are rejected with a
+// validation error.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err == nil && decoder.More() {
+		return services.ValidationError("request body must be a single JSON value", nil)
+	}
+	var sizeErr *http.MaxBytesError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, io.EOF):
+		return errNoBody
+	case errors.As(err, &sizeErr):
+		return services.ValidationError(fmt.Sprintf("request body is larger than %d bytes", sizeErr.Limit), nil)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return services.ValidationError("invalid request body", map[string]string{typeErr.Field: "cannot be a JSON " + typeErr.Value})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		return services.ValidationError("invalid request body", map[string]string{field: "is not allowed"})
+	default:
+		return services.ValidationError("request body is not valid JSON", nil)
+	}
+}
+
 func (h *Handler) AddUser(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	//create container for the incoming user
 	var user models.User
 
 	//take the data from the request body and put it in the empty container
-	json.NewDecoder(r.Body).Decode(&user)
-
-	//username and password length validation
-	if len(user.Name) < 3 || len(user.Password) < 3 {
-		w.WriteHeader(http.StatusBadRequest)
+	err := decodeBody(w, r, &user)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	//call the UserService
-	err := h.service.AddUser(&user)
+	err = h.service.AddUser(r.Context(), &user)
 
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 
@@ -47,20 +90,82 @@ func (h *Handler) AddUser(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) SignIn(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	var user models.User
-	json.NewDecoder(r.Body).Decode(&user)
-	err := h.service.SignIn(&user)
+	err := decodeBody(w, r, &user)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+		writeError(w, r, err)
+		return
+	}
+	err = h.service.SignIn(r.Context(), &user)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
 
+func (h *Handler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	//the password in the body confirms the deletion
+	var user models.User
+	err := decodeBody(w, r, &user)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	user.Name = username
+	err = h.service.DeactivateUser(r.Context(), &user)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("account scheduled for deletion")
+}
+
+func (h *Handler) RenameUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	var rename struct {
+		NewName  string `json:"newname"`
+		Password string `json:"password"`
+	}
+	err := decodeBody(w, r, &rename)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	err = h.service.RenameUser(r.Context(), &models.User{Name: username, Password: rename.Password}, rename.NewName)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("renamed user")
+}
+
+// redirectRenamed sends requests for a handle that was given up recently to
+// the same url with the current handle, which must be the last path segment.
+func (h *Handler) redirectRenamed(w http.ResponseWriter, r *http.Request, username string) bool {
+	current, err := h.service.GetRenamedUsername(r.Context(), username)
+	if err != nil || current == "" {
+		return false
+	}
+	http.Redirect(w, r, path.Dir(r.URL.Path)+"/"+url.PathEscape(current), http.StatusMovedPermanently)
+	return true
+}
+
 func (h *Handler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	users, err := h.service.GetAllUsers()
+	users, err := h.service.GetAllUsers(r.Context())
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode(users)
@@ -71,12 +176,16 @@ func (h *Handler) AddTweet(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	var tweet models.Tweet
 	//get the tweet from request
-	json.NewDecoder(r.Body).Decode(&tweet)
+	err := decodeBody(w, r, &tweet)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
 
-	err := h.service.AddTweet(&tweet)
+	err = h.service.AddTweet(r.Context(), &tweet)
 
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode(&tweet)
@@ -86,9 +195,12 @@ func (h *Handler) AddTweet(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetTweetsOfUser(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	params := mux.Vars(r)
-	tweets, err := h.service.GetTweetsOfUser(params["username"])
+	if h.redirectRenamed(w, r, params["username"]) {
+		return
+	}
+	tweets, err := h.service.GetTweetsOfUser(r.Context(), params["username"])
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode(tweets)
@@ -98,9 +210,12 @@ func (h *Handler) GetTweetsOfUser(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetFolloweesOfUser(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	params := mux.Vars(r)
-	followees, err := h.service.GetFolloweesOfUser(params["username"])
+	if h.redirectRenamed(w, r, params["username"]) {
+		return
+	}
+	followees, err := h.service.GetFolloweesOfUser(r.Context(), params["username"])
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode(followees)
@@ -110,14 +225,17 @@ func (h *Handler) GetFolloweesOfUser(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AddFollowee(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	var follow models.Follows
-	json.NewDecoder(r.Body).Decode(&follow)
+	err := decodeBody(w, r, &follow)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
 
-	err := h.service.AddFollowee(&follow)
+	err = h.service.AddFollowee(r.Context(), &follow)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
-	json.NewDecoder(r.Body).Decode(&follow)
 }
 
 func (h *Handler) DeleteTweet(w http.ResponseWriter, r *http.Request) {
@@ -125,13 +243,13 @@ func (h *Handler) DeleteTweet(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	val, err := strconv.Atoi(params["tweetid"])
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, invalidParam("tweetid"))
 		return
 	}
 
-	err = h.service.DeleteTweet(val)
+	err = h.service.DeleteTweet(r.Context(), val)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode("deleted tweet")
@@ -142,25 +260,57 @@ func (h *Handler) DeleteFollowee(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	params := mux.Vars(r)
 
-	err := h.service.DeleteFollowee(params["username"], params["followeename"])
+	err := h.service.DeleteFollowee(r.Context(), params["username"], params["followeename"])
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode("deleted followee")
 
 }
 
+// CheckFollowing answers 302 when username follows followeename and 404 when
+// it does not.
+//
+// Deprecated: kept for old clients, use GetRelationship instead.
 func (h *Handler) CheckFollowing(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Deprecation", "true")
+	w.Header().Set("Link", `</api/relationship>; rel="successor-version"`)
 	params := mux.Vars(r)
 
-	err := h.service.CheckFollowing(params["username"], params["followeename"])
-
+	relationship, err := h.service.GetRelationship(r.Context(), params["username"], params["followeename"])
+	//old clients read 404 as "not following", which unknown users also are,
+	//and any other error as 400
+	var serviceErr *services.Error
+	if errors.As(err, &serviceErr) && serviceErr.Code == services.CodeNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
 	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if relationship.Following {
 		w.WriteHeader(http.StatusFound)
 		return
 	}
 	w.WriteHeader(http.StatusNotFound)
 
 }
+
+func (h *Handler) GetRelationship(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	source := r.URL.Query().Get("source")
+	target := r.URL.Query().Get("target")
+	if source == "" || target == "" {
+		writeError(w, r, services.ValidationError("source and target are required", map[string]string{"source": "is required", "target": "is required"}))
+		return
+	}
+	relationship, err := h.service.GetRelationship(r.Context(), source, target)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(relationship)
+}
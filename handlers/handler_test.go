@@ -3,12 +3,12 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"example/layered-architecture/models"
 	"example/layered-architecture/services"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -26,7 +26,7 @@ func TestGetAllUsers(t *testing.T) {
 		expectedStatusCode     int
 	}
 	testCases := []testCase{{name: "error", returnUsersFromService: nil,
-		returnErrorFromService: errors.New("some error"),
+		returnErrorFromService: services.ValidationError("some error", nil),
 		expectedStatusCode:     http.StatusBadRequest},
 		{name: "success", returnUsersFromService: nil,
 			returnErrorFromService: nil,
@@ -40,7 +40,7 @@ func TestGetAllUsers(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				GetAllUsers().
+				GetAllUsers(gomock.Any()).
 				Return(test.returnUsersFromService, test.returnErrorFromService).
 				Times(1)
 
@@ -63,7 +63,7 @@ func TestAddUser(t *testing.T) {
 		requestBody            *models.User
 	}
 	testCases := []testCase{{name: "error",
-		returnErrorFromService: errors.New("some error"),
+		returnErrorFromService: services.ValidationError("some error", nil),
 		expectedStatusCode:     http.StatusBadRequest,
 		requestBody: &models.User{
 			Model:    gorm.Model{},
@@ -85,7 +85,7 @@ func TestAddUser(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				AddUser(test.requestBody).
+				AddUser(gomock.Any(), test.requestBody).
 				Return(test.returnErrorFromService).
 				Times(1)
 
@@ -99,6 +99,52 @@ func TestAddUser(t *testing.T) {
 
 }
 
+func TestDecodeBody(t *testing.T) {
+	type testCase struct {
+		name           string
+		body           string
+		expectedError  error
+		expectedFields map[string]string
+	}
+	testCases := []testCase{{name: "valid",
+		body: `{"name":"abc","password":"hunter42"}`},
+		{name: "empty",
+			body:          ``,
+			expectedError: errNoBody},
+		{name: "unknown field",
+			body:           `{"name":"abc","admin":true}`,
+			expectedFields: map[string]string{"admin": "is not allowed"}},
+		{name: "wrong type",
+			body:           `{"name":5}`,
+			expectedFields: map[string]string{"name": "cannot be a JSON number"}},
+		{name: "two values",
+			body:          `{"name":"abc"}{"name":"def"}`,
+			expectedError: services.ValidationError("request body must be a single JSON value", nil)},
+		{name: "too large",
+			body:          `{"name":"` + strings.Repeat("a", maxBodySize) + `"}`,
+			expectedError: services.ValidationError("request body is larger than 65536 bytes", nil)},
+		{name: "not json",
+			body:          `name=abc`,
+			expectedError: services.ValidationError("request body is not valid JSON", nil)}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/api/user", strings.NewReader(test.body))
+			res := httptest.NewRecorder()
+			var user models.User
+
+			err := decodeBody(res, req, &user)
+
+			switch {
+			case test.expectedFields != nil:
+				assert.Equal(t, test.expectedFields, err.(*services.Error).Fields)
+			default:
+				assert.Equal(t, test.expectedError, err)
+			}
+		})
+	}
+}
+
 func TestSignIn(t *testing.T) {
 	type testCase struct {
 		name                   string
@@ -107,7 +153,7 @@ func TestSignIn(t *testing.T) {
 		requestBody            *models.User
 	}
 	testCases := []testCase{{name: "error",
-		returnErrorFromService: errors.New("some error"),
+		returnErrorFromService: services.UnauthorizedError("wrong name or password"),
 		expectedStatusCode:     http.StatusUnauthorized,
 		requestBody: &models.User{
 			Model:    gorm.Model{},
@@ -129,7 +175,7 @@ func TestSignIn(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				SignIn(test.requestBody).
+				SignIn(gomock.Any(), test.requestBody).
 				Return(test.returnErrorFromService).
 				Times(1)
 
@@ -152,7 +198,7 @@ func TestAddTweet(t *testing.T) {
 		requestBody            *models.Tweet
 	}
 	testCases := []testCase{{name: "error",
-		returnErrorFromService: errors.New("some error"),
+		returnErrorFromService: services.ValidationError("some error", nil),
 		expectedStatusCode:     http.StatusBadRequest,
 		requestBody: &models.Tweet{
 			Model:    gorm.Model{},
@@ -174,7 +220,7 @@ func TestAddTweet(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				AddTweet(test.requestBody).
+				AddTweet(gomock.Any(), test.requestBody).
 				Return(test.returnErrorFromService).
 				Times(1)
 
@@ -195,17 +241,22 @@ func TestGetTweetsOfuser(t *testing.T) {
 		paramUsername             string
 		returnedTweetsFromService *[]models.Tweet
 		returnedErrorFromService  error
+		renamedTo                 string
 	}
 	testCases := []testCase{{name: "error",
 		expectedStatusCode:        http.StatusBadRequest,
 		paramUsername:             "abcd",
 		returnedTweetsFromService: &[]models.Tweet{},
-		returnedErrorFromService:  errors.New("some error")},
+		returnedErrorFromService:  services.ValidationError("some error", nil)},
 		{name: "success",
 			expectedStatusCode:        http.StatusOK,
 			paramUsername:             "abc",
 			returnedTweetsFromService: &[]models.Tweet{},
-			returnedErrorFromService:  nil}}
+			returnedErrorFromService:  nil},
+		{name: "renamed",
+			expectedStatusCode: http.StatusMovedPermanently,
+			paramUsername:      "old",
+			renamedTo:          "new"}}
 
 	for _, test := range testCases {
 
@@ -224,15 +275,27 @@ func TestGetTweetsOfuser(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				GetTweetsOfUser(test.paramUsername).
-				Return(test.returnedTweetsFromService, test.returnedErrorFromService).
+				GetRenamedUsername(gomock.Any(), test.paramUsername).
+				Return(test.renamedTo, nil).
 				Times(1)
+			calls := 1
+			if test.renamedTo != "" {
+				calls = 0
+			}
+			mockService.
+				EXPECT().
+				GetTweetsOfUser(gomock.Any(), test.paramUsername).
+				Return(test.returnedTweetsFromService, test.returnedErrorFromService).
+				Times(calls)
 
 			mh := NewHandler(mockService)
 
 			mh.GetTweetsOfUser(res, req)
 
 			assert.Equal(t, test.expectedStatusCode, res.Code)
+			if test.renamedTo != "" {
+				assert.Equal(t, "/api/user/tweets/"+test.renamedTo, res.Header().Get("Location"))
+			}
 		})
 	}
 }
@@ -248,7 +311,7 @@ func TestGetFolloweesOfUser(t *testing.T) {
 		expectedStatusCode:         http.StatusBadRequest,
 		paramUsername:              "abcd",
 		returnedFollowsFromService: &[]models.Follows{},
-		returnedErrorFromService:   errors.New("some error")},
+		returnedErrorFromService:   services.ValidationError("some error", nil)},
 		{name: "success",
 			expectedStatusCode:         http.StatusOK,
 			paramUsername:              "abc",
@@ -269,7 +332,12 @@ func TestGetFolloweesOfUser(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				GetFolloweesOfUser(test.paramUsername).
+				GetRenamedUsername(gomock.Any(), test.paramUsername).
+				Return("", nil).
+				Times(1)
+			mockService.
+				EXPECT().
+				GetFolloweesOfUser(gomock.Any(), test.paramUsername).
 				Return(test.returnedFollowsFromService, test.returnedErrorFromService).
 				Times(1)
 
@@ -288,6 +356,7 @@ func TestCheckFollowing(t *testing.T) {
 		expectedStatusCode       int
 		paramUsername            string
 		paramFolloweename        string
+		returnedFollowing        bool
 		returnedErrorFromService error
 	}
 	testCases := []testCase{
@@ -295,12 +364,24 @@ func TestCheckFollowing(t *testing.T) {
 			expectedStatusCode:       http.StatusFound,
 			paramUsername:            "abcd",
 			paramFolloweename:        "fdfd",
-			returnedErrorFromService: errors.New("some error")},
+			returnedFollowing:        true,
+			returnedErrorFromService: nil},
 		{name: "success",
 			expectedStatusCode:       http.StatusNotFound,
 			paramUsername:            "abc",
 			paramFolloweename:        "dfdfdf",
-			returnedErrorFromService: nil}}
+			returnedFollowing:        false,
+			returnedErrorFromService: nil},
+		{name: "unknown followee",
+			expectedStatusCode:       http.StatusNotFound,
+			paramUsername:            "abc",
+			paramFolloweename:        "nobody",
+			returnedErrorFromService: services.NotFoundError("users not found")},
+		{name: "error",
+			expectedStatusCode:       http.StatusBadRequest,
+			paramUsername:            "abc",
+			paramFolloweename:        "dfdfdf",
+			returnedErrorFromService: services.ValidationError("some error", nil)}}
 
 	for _, test := range testCases {
 
@@ -316,8 +397,8 @@ func TestCheckFollowing(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				CheckFollowing(test.paramUsername, test.paramFolloweename).
-				Return(test.returnedErrorFromService).
+				GetRelationship(gomock.Any(), test.paramUsername, test.paramFolloweename).
+				Return(&models.Relationship{Following: test.returnedFollowing}, test.returnedErrorFromService).
 				Times(1)
 
 			mh := NewHandler(mockService)
@@ -325,6 +406,57 @@ func TestCheckFollowing(t *testing.T) {
 			mh.CheckFollowing(res, req)
 
 			assert.Equal(t, test.expectedStatusCode, res.Code)
+			assert.Equal(t, "true", res.Header().Get("Deprecation"))
+		})
+	}
+}
+
+func TestGetRelationship(t *testing.T) {
+	type testCase struct {
+		name                     string
+		query                    string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{
+		{name: "missing target",
+			query:              "?source=abc",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCalls:      0},
+		{name: "error",
+			query:                    "?source=abc&target=def",
+			expectedStatusCode:       http.StatusBadRequest,
+			expectedCalls:            1,
+			returnedErrorFromService: services.ValidationError("some error", nil)},
+		{name: "success",
+			query:                    "?source=abc&target=def",
+			expectedStatusCode:       http.StatusOK,
+			expectedCalls:            1,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/api/relationship"+test.query, http.NoBody)
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				GetRelationship(gomock.Any(), "abc", "def").
+				Return(&models.Relationship{Source: "abc", Target: "def", FollowedBy: true}, test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+
+			mh.GetRelationship(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+			if test.expectedStatusCode == http.StatusOK {
+				var relationship map[string]interface{}
+				json.NewDecoder(res.Body).Decode(&relationship)
+				assert.Equal(t, true, relationship["followed_by"])
+				assert.Equal(t, false, relationship["following"])
+			}
 		})
 	}
 }
@@ -338,7 +470,7 @@ func TestAddFollowee(t *testing.T) {
 	}
 	testCases := []testCase{{name: "error",
 		expectedStatusCode:       http.StatusBadRequest,
-		returnedErrorFromService: errors.New("some error"),
+		returnedErrorFromService: services.ValidationError("some error", nil),
 		requestBody: &models.Follows{
 			Model:      gorm.Model{},
 			SourceUser: "abc",
@@ -362,7 +494,7 @@ func TestAddFollowee(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				AddFollowee(test.requestBody).
+				AddFollowee(gomock.Any(), test.requestBody).
 				Return(test.returnedErrorFromService).
 				Times(1)
 
@@ -384,7 +516,7 @@ func TestDeleteTweet(t *testing.T) {
 	}
 	testCases := []testCase{{name: "error",
 		expectedStatusCode:       http.StatusBadRequest,
-		returnedErrorFromService: errors.New("some error"),
+		returnedErrorFromService: services.ValidationError("some error", nil),
 		paramId:                  "1"},
 		{name: "success",
 			expectedStatusCode:       http.StatusOK,
@@ -405,7 +537,7 @@ func TestDeleteTweet(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				DeleteTweet(val).
+				DeleteTweet(gomock.Any(), val).
 				Return(test.returnedErrorFromService).
 				Times(1)
 
@@ -428,7 +560,7 @@ func TestDeleteFollowee(t *testing.T) {
 	}
 	testCases := []testCase{{name: "error",
 		expectedStatusCode:       http.StatusBadRequest,
-		returnedErrorFromService: errors.New("some error"),
+		returnedErrorFromService: services.ValidationError("some error", nil),
 		paramSourceUsername:      "adfd",
 		paramTargetUsername:      "fdfdf"},
 		{name: "success",
@@ -451,7 +583,7 @@ func TestDeleteFollowee(t *testing.T) {
 			mockService := services.NewMockServiceInterface(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				DeleteFollowee(test.paramSourceUsername, test.paramTargetUsername).
+				DeleteFollowee(gomock.Any(), test.paramSourceUsername, test.paramTargetUsername).
 				Return(test.returnedErrorFromService).
 				Times(1)
 
@@ -463,3 +595,91 @@ func TestDeleteFollowee(t *testing.T) {
 		})
 	}
 }
+
+func TestDeactivateUser(t *testing.T) {
+	type testCase struct {
+		name                     string
+		username                 string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "no user",
+		username:           "",
+		expectedStatusCode: http.StatusUnauthorized,
+		expectedCalls:      0},
+		{name: "wrong password",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusUnauthorized,
+			expectedCalls:            1,
+			returnedErrorFromService: services.UnauthorizedError("wrong password")},
+		{name: "success",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusOK,
+			expectedCalls:            1,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodDelete, "/api/user/me", bytes.NewBufferString(`{"password":"secret"}`))
+			req.Header.Set("X-Username", test.username)
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				DeactivateUser(gomock.Any(), &models.User{Name: test.username, Password: "secret"}).
+				Return(test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+
+			mh.DeactivateUser(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
+
+func TestRenameUser(t *testing.T) {
+	type testCase struct {
+		name                     string
+		username                 string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "no user",
+		username:           "",
+		expectedStatusCode: http.StatusUnauthorized,
+		expectedCalls:      0},
+		{name: "handle reserved",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusBadRequest,
+			expectedCalls:            1,
+			returnedErrorFromService: services.ValidationError("some error", nil)},
+		{name: "success",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusOK,
+			expectedCalls:            1,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPut, "/api/user/me/username", bytes.NewBufferString(`{"newname":"xyz","password":"secret"}`))
+			req.Header.Set("X-Username", test.username)
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				RenameUser(gomock.Any(), &models.User{Name: test.username, Password: "secret"}, "xyz").
+				Return(test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+
+			mh.RenameUser(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// checkTimeout bounds every readiness check, a probe must not hang.
+const checkTimeout = 2 * time.Second
+
+// Check reports whether a dependency of the server works.
+type Check func(ctx context.Context) error
+
+// Health answers the probes of the orchestrator: Live whether the process is
+// up, Ready whether it can serve requests.
+type Health struct {
+	mu     sync.Mutex
+	checks map[string]Check
+}
+
+func NewHealth() *Health {
+	return &Health{checks: map[string]Check{}}
+}
+
+// Add adds a check to the readiness probe.
+func (h *Health) Add(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+type checkResult struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+type healthResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks,omitempty"`
+}
+
+// Live answers as long as the process can handle requests at all.
+func (h *Health) Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(healthResponse{Status: "ok"})
+}
+
+// Ready runs all checks at once and answers 503 when any of them fails, along
+// with the outcome and latency of every check.
+func (h *Health) Ready(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	h.mu.Lock()
+	checks := make(map[string]Check, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mu.Unlock()
+
+	response := healthResponse{Status: "ok", Checks: map[string]checkResult{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+			defer cancel()
+			start := time.Now()
+			err := check(ctx)
+			result := checkResult{Status: "ok", Latency: time.Since(start).String()}
+			if err != nil {
+				result.Status = "failing"
+				result.Error = err.Error()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			response.Checks[name] = result
+			if err != nil {
+				response.Status = "unavailable"
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if response.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(response)
+}
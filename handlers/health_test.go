@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLive(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/healthz", http.NoBody)
+	rr := httptest.NewRecorder()
+
+	NewHealth().Live(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rr.Body.String())
+}
+
+func TestReady(t *testing.T) {
+	type testCase struct {
+		name               string
+		checks             map[string]Check
+		expectedStatusCode int
+		expectedStatus     string
+		expectedChecks     map[string]string
+	}
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+	hanging := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	testCases := []testCase{{name: "ready",
+		checks:             map[string]Check{"database": ok, "migrations": ok},
+		expectedStatusCode: http.StatusOK,
+		expectedStatus:     "ok",
+		expectedChecks:     map[string]string{"database": "", "migrations": ""}},
+		{name: "failing",
+			checks:             map[string]Check{"database": failing, "migrations": ok},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     "unavailable",
+			expectedChecks:     map[string]string{"database": "connection refused", "migrations": ""}},
+		{name: "hanging",
+			checks:             map[string]Check{"database": hanging},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     "unavailable",
+			expectedChecks:     map[string]string{"database": "context deadline exceeded"}}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			health := NewHealth()
+			for name, check := range test.checks {
+				health.Add(name, check)
+			}
+			//the probe's own deadline cuts hanging checks short
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/readyz", http.NoBody)
+			rr := httptest.NewRecorder()
+
+			health.Ready(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			var body healthResponse
+			json.NewDecoder(rr.Body).Decode(&body)
+			assert.Equal(t, test.expectedStatus, body.Status)
+			errs := map[string]string{}
+			for name, result := range body.Checks {
+				errs[name] = result.Error
+				assert.NotEmpty(t, result.Latency)
+			}
+			assert.Equal(t, test.expectedChecks, errs)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"encoding/json"
+	"example/layered-architecture/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) AddList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	var list models.List
+	err := decodeBody(w, r, &list)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	list.OwnerName = username
+	err = h.service.AddList(r.Context(), &list)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(&list)
+}
+
+func (h *Handler) GetLists(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	lists, err := h.service.GetListsOfUser(r.Context(), username)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(lists)
+}
+
+func (h *Handler) GetList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["listid"])
+	if err != nil {
+		writeError(w, r, invalidParam("listid"))
+		return
+	}
+	list, err := h.service.GetList(r.Context(), currentUser(r), val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+func (h *Handler) DeleteList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["listid"])
+	if err != nil {
+		writeError(w, r, invalidParam("listid"))
+		return
+	}
+	err = h.service.DeleteList(r.Context(), currentUser(r), val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("deleted list")
+}
+
+func (h *Handler) GetListMembers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["listid"])
+	if err != nil {
+		writeError(w, r, invalidParam("listid"))
+		return
+	}
+	members, err := h.service.GetListMembers(r.Context(), currentUser(r), val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(members)
+}
+
+func (h *Handler) AddListMember(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["listid"])
+	if err != nil {
+		writeError(w, r, invalidParam("listid"))
+		return
+	}
+	var member models.ListMember
+	err = decodeBody(w, r, &member)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	member.ListID = uint(val)
+	err = h.service.AddListMember(r.Context(), currentUser(r), &member)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(&member)
+}
+
+func (h *Handler) DeleteListMember(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["listid"])
+	if err != nil {
+		writeError(w, r, invalidParam("listid"))
+		return
+	}
+	err = h.service.DeleteListMember(r.Context(), currentUser(r), val, params["username"])
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("deleted member")
+}
+
+func (h *Handler) SubscribeList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["listid"])
+	if err != nil {
+		writeError(w, r, invalidParam("listid"))
+		return
+	}
+	err = h.service.SubscribeList(r.Context(), username, val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("subscribed")
+}
+
+func (h *Handler) UnsubscribeList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["listid"])
+	if err != nil {
+		writeError(w, r, invalidParam("listid"))
+		return
+	}
+	err = h.service.UnsubscribeList(r.Context(), username, val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("unsubscribed")
+}
+
+func (h *Handler) GetListTimeline(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["listid"])
+	if err != nil {
+		writeError(w, r, invalidParam("listid"))
+		return
+	}
+	tweets, err := h.service.GetListTimeline(r.Context(), currentUser(r), val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(tweets)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"example/layered-architecture/models"
+	"example/layered-architecture/services"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetListTimeline(t *testing.T) {
+	type testCase struct {
+		name                     string
+		expectedStatusCode       int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "not visible",
+		expectedStatusCode:       http.StatusNotFound,
+		returnedErrorFromService: services.NotFoundError("list not found")},
+		{name: "success",
+			expectedStatusCode:       http.StatusOK,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/api/lists/", http.NoBody)
+			req.Header.Set("X-Username", "abc")
+			req = mux.SetURLVars(req, map[string]string{"listid": "3"})
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				GetListTimeline(gomock.Any(), "abc", 3).
+				Return(&[]models.Tweet{}, test.returnedErrorFromService).
+				Times(1)
+
+			mh := NewHandler(mockService)
+
+			mh.GetListTimeline(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
+
+func TestAddList(t *testing.T) {
+	type testCase struct {
+		name                     string
+		username                 string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "no user",
+		username:           "",
+		expectedStatusCode: http.StatusUnauthorized,
+		expectedCalls:      0},
+		{name: "error",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusBadRequest,
+			expectedCalls:            1,
+			returnedErrorFromService: services.ValidationError("some error", nil)},
+		{name: "success",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusCreated,
+			expectedCalls:            1,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/api/lists", strings.NewReader(`{"title":"friends"}`))
+			req.Header.Set("X-Username", test.username)
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				AddList(gomock.Any(), &models.List{OwnerName: test.username, Title: "friends"}).
+				Return(test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+
+			mh.AddList(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"example/layered-architecture/logging"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// RequestIDHeader carries the id of a request, both ways.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern is what an id sent by the client must look like to be
+// kept, anything else is replaced so it cannot forge log lines.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID gives every request an id, taken from the X-Request-ID header of
+// a proxy in front or made up. The id is on the context for the logs of all
+// layers and is sent back in the same header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), id)))
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 12)
+	//crypto/rand does not fail on the platforms we run on
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// AccessLog logs every request once it is done. Path variables with
+// sensitive names, such as the token of an export download, are redacted.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		route := ""
+		if current := mux.CurrentRoute(r); current != nil {
+			route, _ = current.GetPathTemplate()
+		}
+		level := slog.LevelInfo
+		if recorder.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.LogAttrs(r.Context(), level, "request",
+			slog.String("method", r.Method),
+			slog.String("path", redactedPath(r)),
+			slog.String("route", route),
+			slog.Int("status", recorder.status),
+			slog.Int64("bytes", recorder.written),
+			slog.String("duration", time.Since(start).String()),
+			slog.String("user", currentUser(r)),
+			slog.String("remote", r.RemoteAddr),
+		)
+	})
+}
+
+func redactedPath(r *http.Request) string {
+	path := r.URL.Path
+	for name, value := range mux.Vars(r) {
+		if logging.IsSensitive(name) && value != "" {
+			path = strings.ReplaceAll(path, value, logging.Redacted)
+		}
+	}
+	return path
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"example/layered-architecture/logging"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	type testCase struct {
+		name       string
+		header     string
+		expectedID string
+	}
+	testCases := []testCase{{name: "from proxy",
+		header:     "abc-123",
+		expectedID: "abc-123"},
+		{name: "made up",
+			header: ""},
+		{name: "forged",
+			header: "abc\n{\"level\":\"ERROR\"}"}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var seen string
+			h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = logging.RequestID(r.Context())
+			}))
+			req, _ := http.NewRequest(http.MethodGet, "/api/user", http.NoBody)
+			req.Header.Set(RequestIDHeader, test.header)
+			rr := httptest.NewRecorder()
+
+			h.ServeHTTP(rr, req)
+
+			assert.Equal(t, seen, rr.Header().Get(RequestIDHeader))
+			if test.expectedID != "" {
+				assert.Equal(t, test.expectedID, seen)
+			} else {
+				assert.Len(t, seen, 24)
+			}
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var out bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(logging.New(&out, "info", "json"))
+	r := mux.NewRouter()
+	r.Use(AccessLog)
+	r.HandleFunc("/api/exports/{token}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("archive"))
+	})
+	req, _ := http.NewRequest(http.MethodGet, "/api/exports/s3cr3t", http.NoBody)
+	req = req.WithContext(logging.WithRequestID(req.Context(), "req-1"))
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]interface{}
+	err := json.Unmarshal(out.Bytes(), &record)
+	assert.NoError(t, err)
+	assert.Equal(t, "request", record["msg"])
+	assert.Equal(t, "/api/exports/[REDACTED]", record["path"])
+	assert.Equal(t, "/api/exports/{token}", record["route"])
+	assert.Equal(t, float64(200), record["status"])
+	assert.Equal(t, float64(7), record["bytes"])
+	assert.Equal(t, "req-1", record["requestid"])
+	assert.NotContains(t, out.String(), "s3cr3t")
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"example/layered-architecture/services"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	//leave some room for the multipart framing around the file
+	r.Body = http.MaxBytesReader(w, r.Body, services.MaxMediaSize+1<<20)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, r, services.ValidationError("a file is required", map[string]string{"file": "is required"}))
+		return
+	}
+	defer file.Close()
+	media, err := h.service.UploadMedia(r.Context(), username, file)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(media)
+}
+
+func (h *Handler) GetMedia(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["mediaid"])
+	if err != nil {
+		writeError(w, r, invalidParam("mediaid"))
+		return
+	}
+	thumbnail := r.URL.Query().Get("thumbnail") == "true"
+	media, blob, err := h.service.GetMedia(r.Context(), val, thumbnail)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	defer blob.Close()
+	contentType := media.MimeType
+	if thumbnail {
+		contentType = "image/jpeg"
+	}
+	w.Header().Set("Content-Type", contentType)
+	io.Copy(w, blob)
+}
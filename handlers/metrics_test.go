@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	type testCase struct {
+		name         string
+		method       string
+		path         string
+		expectedCode string
+	}
+	testCases := []testCase{{name: "ok",
+		method:       http.MethodGet,
+		path:         "/api/tweet/1",
+		expectedCode: "200"},
+		{name: "error status",
+			method:       http.MethodDelete,
+			path:         "/api/tweet/2",
+			expectedCode: "404"}}
+
+	r := mux.NewRouter()
+	r.Use(Metrics)
+	r.HandleFunc("/api/tweet/{tweetid}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, 1.0, testutil.ToFloat64(requestsInFlight.WithLabelValues("/api/tweet/{tweetid}")))
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			before := testutil.ToFloat64(requestsTotal.WithLabelValues("/api/tweet/{tweetid}", test.method, test.expectedCode))
+			req, _ := http.NewRequest(test.method, test.path, http.NoBody)
+
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			//counted under the template, whatever the tweet id
+			assert.Equal(t, before+1, testutil.ToFloat64(requestsTotal.WithLabelValues("/api/tweet/{tweetid}", test.method, test.expectedCode)))
+			assert.Equal(t, 0.0, testutil.ToFloat64(requestsInFlight.WithLabelValues("/api/tweet/{tweetid}")))
+		})
+	}
+}
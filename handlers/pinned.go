@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (h *Handler) PinTweet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	var pin struct {
+		TweetID int `json:"tweetid"`
+	}
+	err := decodeBody(w, r, &pin)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	err = h.service.PinTweet(r.Context(), username, pin.TweetID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("pinned tweet")
+}
+
+func (h *Handler) UnpinTweet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	err := h.service.UnpinTweet(r.Context(), username)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode("unpinned tweet")
+}
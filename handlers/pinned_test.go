@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"bytes"
+	"example/layered-architecture/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinTweet(t *testing.T) {
+	type testCase struct {
+		name                     string
+		username                 string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "no user",
+		username:           "",
+		expectedStatusCode: http.StatusUnauthorized,
+		expectedCalls:      0},
+		{name: "not own tweet",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusBadRequest,
+			expectedCalls:            1,
+			returnedErrorFromService: services.ValidationError("some error", nil)},
+		{name: "success",
+			username:                 "abc",
+			expectedStatusCode:       http.StatusOK,
+			expectedCalls:            1,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPut, "/api/user/me/pinned", bytes.NewBufferString(`{"tweetid":5}`))
+			req.Header.Set("X-Username", test.username)
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				PinTweet(gomock.Any(), test.username, 5).
+				Return(test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+
+			mh.PinTweet(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
+
+func TestUnpinTweet(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodDelete, "/api/user/me/pinned", http.NoBody)
+	req.Header.Set("X-Username", "abc")
+	res := httptest.NewRecorder()
+	mockService := services.NewMockServiceInterface(gomock.NewController(t))
+	mockService.
+		EXPECT().
+		UnpinTweet(gomock.Any(), "abc").
+		Return(nil).
+		Times(1)
+
+	mh := NewHandler(mockService)
+
+	mh.UnpinTweet(res, req)
+
+	assert.Equal(t, http.StatusOK, res.Code)
+}
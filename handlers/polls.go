@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetPoll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["tweetid"])
+	if err != nil {
+		writeError(w, r, invalidParam("tweetid"))
+		return
+	}
+	poll, err := h.service.GetPoll(r.Context(), currentUser(r), val)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(poll)
+}
+
+func (h *Handler) VotePoll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	username := currentUser(r)
+	if username == "" {
+		writeError(w, r, errNoUser)
+		return
+	}
+	params := mux.Vars(r)
+	val, err := strconv.Atoi(params["tweetid"])
+	if err != nil {
+		writeError(w, r, invalidParam("tweetid"))
+		return
+	}
+	var vote struct {
+		OptionID uint `json:"optionid"`
+	}
+	err = decodeBody(w, r, &vote)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	poll, err := h.service.VotePoll(r.Context(), username, val, vote.OptionID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(poll)
+}
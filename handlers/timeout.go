@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// deadlineSlack keeps connections open a little longer than the deadline of
+// their request, so that a request that timed out still gets its error.
+const deadlineSlack = 5 * time.Second
+
+// Timeouts are the deadlines of requests. Routes are named by their path
+// template, routes without an entry get Default.
+//
+// The read and write timeouts of the server are meant for Default. Routes
+// with an entry get their connection deadlines moved to match theirs, so
+// that long uploads and downloads are not cut off by the server.
+type Timeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// For returns the deadline of the route with the given path template.
+func (t Timeouts) For(template string) time.Duration {
+	if d, ok := t.Routes[template]; ok {
+		return d
+	}
+	return t.Default
+}
+
+// Middleware puts the deadline of the matched route on the request context.
+// Queries still running when it passes are cancelled and the request fails
+// with a timeout error. A zero deadline leaves the request without one.
+func (t Timeouts) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		template := ""
+		if route := mux.CurrentRoute(r); route != nil {
+			template, _ = route.GetPathTemplate()
+		}
+		d, ok := t.Routes[template]
+		if !ok {
+			d = t.Default
+		} else {
+			//not every ResponseWriter has a connection, recorders in tests
+			//for one, so errors are ignored
+			rc := http.NewResponseController(w)
+			deadline := time.Time{}
+			if d > 0 {
+				deadline = time.Now().Add(d + deadlineSlack)
+			}
+			_ = rc.SetReadDeadline(deadline)
+			_ = rc.SetWriteDeadline(deadline)
+		}
+		if d <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"example/layered-architecture/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutsMiddleware(t *testing.T) {
+	type testCase struct {
+		name             string
+		path             string
+		expectedDeadline time.Duration
+	}
+	testCases := []testCase{{name: "default",
+		path:             "/api/user/tweets/abc",
+		expectedDeadline: time.Second},
+		{name: "route",
+			path:             "/api/media",
+			expectedDeadline: time.Minute},
+		{name: "none",
+			path:             "/api/admin/export/users",
+			expectedDeadline: 0}}
+	timeouts := Timeouts{Default: time.Second, Routes: map[string]time.Duration{
+		"/api/media":               time.Minute,
+		"/api/admin/export/{kind}": 0,
+	}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var remaining time.Duration
+			record := func(w http.ResponseWriter, r *http.Request) {
+				if deadline, ok := r.Context().Deadline(); ok {
+					remaining = time.Until(deadline)
+				}
+			}
+			r := mux.NewRouter()
+			r.Use(timeouts.Middleware)
+			r.HandleFunc("/api/user/tweets/{username}", record)
+			r.HandleFunc("/api/media", record)
+			r.HandleFunc("/api/admin/export/{kind}", record)
+			req, _ := http.NewRequest(http.MethodGet, test.path, http.NoBody)
+
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.InDelta(t, test.expectedDeadline, remaining, float64(100*time.Millisecond))
+		})
+	}
+}
+
+func TestCancellation(t *testing.T) {
+	type testCase struct {
+		name               string
+		ctx                func() (context.Context, context.CancelFunc)
+		expectedStatusCode int
+	}
+	testCases := []testCase{{name: "deadline passed",
+		ctx: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), time.Millisecond)
+		},
+		expectedStatusCode: http.StatusServiceUnavailable},
+		{name: "client gone",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			expectedStatusCode: statusClientClosedRequest}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := test.ctx()
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/api/user/tweets/abc", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"username": "abc"})
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				GetRenamedUsername(gomock.Any(), "abc").
+				Return("", nil)
+			//a query blocks until its context is done
+			mockService.
+				EXPECT().
+				GetTweetsOfUser(gomock.Any(), "abc").
+				DoAndReturn(func(ctx context.Context, username string) (*[]models.Tweet, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}).
+				Times(1)
+
+			mh := NewHandler(mockService)
+
+			mh.GetTweetsOfUser(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func transferFormat(r *http.Request) string {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return "ndjson"
+	}
+	return format
+}
+
+// ImportData imports the users, tweets or follows in the request body. Rows
+// that cannot be imported are listed in the report, the rest still goes in.
+func (h *Handler) ImportData(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(r)
+	report, err := h.service.Import(r.Context(), params["kind"], transferFormat(r), r.Body)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// ExportData streams all users, tweets or follows. Users are exported with
+// their passwords, in plain text, only with ?passwords=true; importing them
+// on another deployment needs them.
+func (h *Handler) ExportData(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	format := transferFormat(r)
+	contentType := "application/x-ndjson"
+	if format == "csv" {
+		contentType = "text/csv"
+	}
+	w.Header().Set("Content-Type", contentType)
+	recorder := &statusRecorder{ResponseWriter: w}
+	passwords := r.URL.Query().Get("passwords") == "true"
+	err := h.service.Export(r.Context(), params["kind"], format, passwords, recorder)
+	if err == nil {
+		return
+	}
+	if recorder.status == 0 {
+		writeError(w, r, err)
+		return
+	}
+	//the 200 is already out, an error body would pass for the end of the
+	//export; breaking the connection tells the client it is incomplete
+	slog.ErrorContext(r.Context(), "export failed while streaming", "kind", params["kind"], "error", err)
+	panic(http.ErrAbortHandler)
+}
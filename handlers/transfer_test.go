@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/services"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportData(t *testing.T) {
+	type testCase struct {
+		name                     string
+		token                    string
+		authorization            string
+		expectedStatusCode       int
+		expectedCalls            int
+		returnedErrorFromService error
+	}
+	testCases := []testCase{{name: "admin api off",
+		token:              "",
+		authorization:      "Bearer ",
+		expectedStatusCode: http.StatusForbidden,
+		expectedCalls:      0},
+		{name: "wrong token",
+			token:              "secret",
+			authorization:      "Bearer guess",
+			expectedStatusCode: http.StatusForbidden,
+			expectedCalls:      0},
+		{name: "error",
+			token:                    "secret",
+			authorization:            "Bearer secret",
+			expectedStatusCode:       http.StatusBadRequest,
+			expectedCalls:            1,
+			returnedErrorFromService: services.ValidationError("some error", nil)},
+		{name: "success",
+			token:                    "secret",
+			authorization:            "Bearer secret",
+			expectedStatusCode:       http.StatusOK,
+			expectedCalls:            1,
+			returnedErrorFromService: nil}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/api/admin/import/users?format=csv", strings.NewReader("name,password\n"))
+			req.Header.Set("Authorization", test.authorization)
+			req = mux.SetURLVars(req, map[string]string{"kind": "users"})
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				Import(gomock.Any(), "users", "csv", gomock.Any()).
+				Return(&models.ImportReport{}, test.returnedErrorFromService).
+				Times(test.expectedCalls)
+
+			mh := NewHandler(mockService)
+			RequireAdminToken(test.token, mh.ImportData)(res, req)
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
+
+func TestExportData(t *testing.T) {
+	type testCase struct {
+		name               string
+		query              string
+		passwords          bool
+		written            string
+		expectedStatusCode int
+		expectedAbort      bool
+	}
+	testCases := []testCase{{name: "fails before writing",
+		query:              "",
+		passwords:          false,
+		written:            "",
+		expectedStatusCode: http.StatusInternalServerError,
+		expectedAbort:      false},
+		{name: "fails while streaming",
+			query:              "?passwords=true",
+			passwords:          true,
+			written:            "{\"name\":\"abc\"}\n",
+			expectedStatusCode: http.StatusOK,
+			expectedAbort:      true}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/api/admin/export/users"+test.query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"kind": "users"})
+			res := httptest.NewRecorder()
+			mockService := services.NewMockServiceInterface(gomock.NewController(t))
+			mockService.
+				EXPECT().
+				Export(gomock.Any(), "users", "ndjson", test.passwords, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, kind string, format string, passwords bool, w io.Writer) error {
+					if test.written != "" {
+						io.WriteString(w, test.written)
+					}
+					return errors.New("connection lost")
+				})
+
+			mh := NewHandler(mockService)
+			serve := func() { mh.ExportData(res, req) }
+			if test.expectedAbort {
+				//the client sees the connection break instead of an error
+				//body appended to the rows
+				assert.PanicsWithValue(t, http.ErrAbortHandler, serve)
+				assert.Equal(t, test.written, res.Body.String())
+			} else {
+				serve()
+			}
+
+			assert.Equal(t, test.expectedStatusCode, res.Code)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(&out, "info", "json")
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	logger.DebugContext(ctx, "hidden")
+	logger.InfoContext(ctx, "signed in", "user", "abc", "password", "hunter2", "Authorization", "Bearer xyz")
+
+	var record map[string]interface{}
+	err := json.Unmarshal(out.Bytes(), &record)
+	assert.NoError(t, err)
+	assert.Equal(t, "signed in", record["msg"])
+	assert.Equal(t, "req-1", record[RequestIDKey])
+	assert.Equal(t, "abc", record["user"])
+	assert.Equal(t, Redacted, record["password"])
+	assert.Equal(t, Redacted, record["Authorization"])
+	assert.NotContains(t, out.String(), "hunter2")
+}
+
+func TestNewSpan(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(&out, "info", "json")
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3},
+		SpanID:  trace.SpanID{4, 5, 6},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), span)
+
+	logger.InfoContext(ctx, "query")
+
+	var record map[string]interface{}
+	err := json.Unmarshal(out.Bytes(), &record)
+	assert.NoError(t, err)
+	assert.Equal(t, span.TraceID().String(), record[TraceIDKey])
+	assert.Equal(t, span.SpanID().String(), record[SpanIDKey])
+}
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, ParseLevel("debug"))
+	assert.Equal(t, slog.LevelWarn, ParseLevel("warn"))
+	assert.Equal(t, slog.LevelInfo, ParseLevel("verbose"))
+}
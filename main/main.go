@@ -1,19 +1,49 @@
 package main
 
 import (
+	"context"
+	"example/layered-architecture/config"
 	"example/layered-architecture/handlers"
+	"example/layered-architecture/logging"
 	"example/layered-architecture/repositories"
 	"example/layered-architecture/services"
+	"example/layered-architecture/storage"
+	"example/layered-architecture/tracing"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	_ "github.com/golang/mock/mockgen/model"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 )
 
-func setUpRoutes(handler *handlers.Handler) {
+func setUpRoutes(handler *handlers.Handler, health *handlers.Health, cfg *config.Config) http.Handler {
 	r := mux.NewRouter().StrictSlash(true)
+	r.Use(handlers.Tracing, handlers.AccessLog, handlers.Metrics)
+	//requests that match no route are logged too
+	r.NotFoundHandler = handlers.AccessLog(http.NotFoundHandler())
+	r.MethodNotAllowedHandler = handlers.AccessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	//bounds how long a request may take, including its queries
+	requestTimeouts := handlers.Timeouts{Default: cfg.Server.RequestTimeout, Routes: cfg.Server.RouteTimeouts}
+	r.Use(requestTimeouts.Middleware)
+	adminToken := cfg.Admin.Token
+
+	//probes of the orchestrator
+	r.HandleFunc("/healthz", health.Live).Methods("GET")
+	r.HandleFunc("/readyz", health.Ready).Methods("GET")
+	r.Handle("/metrics", promhttp.Handler()).Methods("GET")
 
 	//routes for the apis
 	r.HandleFunc("/api/signin", handler.SignIn).Methods("POST")
@@ -26,10 +56,46 @@ func setUpRoutes(handler *handlers.Handler) {
 	r.HandleFunc("/api/tweet/{tweetid}", handler.DeleteTweet).Methods("DELETE")
 	r.HandleFunc("/api/user/followees/{username}/{followeename}", handler.DeleteFollowee).Methods("DELETE")
 	r.HandleFunc("/api/user/followees/{username}/{followeename}", handler.CheckFollowing).Methods("GET")
+	r.HandleFunc("/api/relationship", handler.GetRelationship).Methods("GET")
+	r.HandleFunc("/api/media", handler.UploadMedia).Methods("POST")
+	r.HandleFunc("/api/media/{mediaid}", handler.GetMedia).Methods("GET")
+	r.HandleFunc("/api/user/me", handler.DeactivateUser).Methods("DELETE")
+	r.HandleFunc("/api/user/me/username", handler.RenameUser).Methods("PUT")
+	r.HandleFunc("/api/user/me/pinned", handler.PinTweet).Methods("PUT")
+	r.HandleFunc("/api/user/me/pinned", handler.UnpinTweet).Methods("DELETE")
+	r.HandleFunc("/api/user/me/export", handler.RequestDataExport).Methods("POST")
+	r.HandleFunc("/api/user/me/export", handler.GetDataExport).Methods("GET")
+	r.HandleFunc("/api/exports/{token}", handler.DownloadDataExport).Methods("GET")
+	r.HandleFunc("/api/tweet/{tweetid}/poll", handler.GetPoll).Methods("GET")
+	r.HandleFunc("/api/tweet/{tweetid}/poll/vote", handler.VotePoll).Methods("POST")
+	r.HandleFunc("/api/tweet/{tweetid}/bookmark", handler.AddBookmark).Methods("POST")
+	r.HandleFunc("/api/tweet/{tweetid}/bookmark", handler.DeleteBookmark).Methods("DELETE")
+	r.HandleFunc("/api/bookmarks", handler.GetBookmarks).Methods("GET")
+	r.HandleFunc("/api/bookmarks/folders", handler.GetBookmarkFolders).Methods("GET")
+	r.HandleFunc("/api/bookmarks/folders", handler.AddBookmarkFolder).Methods("POST")
+	r.HandleFunc("/api/bookmarks/folders/{folderid}", handler.DeleteBookmarkFolder).Methods("DELETE")
+	r.HandleFunc("/api/lists", handler.GetLists).Methods("GET")
+	r.HandleFunc("/api/lists", handler.AddList).Methods("POST")
+	r.HandleFunc("/api/lists/{listid}", handler.GetList).Methods("GET")
+	r.HandleFunc("/api/lists/{listid}", handler.DeleteList).Methods("DELETE")
+	r.HandleFunc("/api/lists/{listid}/members", handler.GetListMembers).Methods("GET")
+	r.HandleFunc("/api/lists/{listid}/members", handler.AddListMember).Methods("POST")
+	r.HandleFunc("/api/lists/{listid}/members/{username}", handler.DeleteListMember).Methods("DELETE")
+	r.HandleFunc("/api/lists/{listid}/subscribers", handler.SubscribeList).Methods("POST")
+	r.HandleFunc("/api/lists/{listid}/subscribers", handler.UnsubscribeList).Methods("DELETE")
+	r.HandleFunc("/api/lists/{listid}/timeline", handler.GetListTimeline).Methods("GET")
+	r.HandleFunc("/api/suggestions", handler.GetSuggestions).Methods("GET")
+	r.HandleFunc("/api/drafts", handler.GetDrafts).Methods("GET")
+	r.HandleFunc("/api/drafts", handler.AddDraft).Methods("POST")
+	r.HandleFunc("/api/drafts/{draftid}", handler.GetDraft).Methods("GET")
+	r.HandleFunc("/api/drafts/{draftid}", handler.UpdateDraft).Methods("PUT")
+	r.HandleFunc("/api/drafts/{draftid}", handler.DeleteDraft).Methods("DELETE")
+	r.HandleFunc("/api/admin/import/{kind}", handlers.RequireAdminToken(adminToken, handler.ImportData)).Methods("POST")
+	r.HandleFunc("/api/admin/export/{kind}", handlers.RequireAdminToken(adminToken, handler.ExportData)).Methods("GET")
 
 	//allowing CORS for the client
 	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:3000"},
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
 		AllowCredentials: true,
 		AllowedMethods: []string{
 			http.MethodGet, //http methods for your app
@@ -40,23 +106,160 @@ func setUpRoutes(handler *handlers.Handler) {
 			http.MethodOptions,
 			http.MethodHead,
 		},
+		AllowedHeaders: []string{"Content-Type", "X-Username", "Authorization", handlers.RequestIDHeader, "traceparent", "tracestate"},
+		ExposedHeaders: []string{handlers.RequestIDHeader},
+	})
+
+	//outermost, so that every response has an id
+	return handlers.RequestID(c.Handler(r))
+}
+
+// accountGracePeriod is how long a deleted account can still be restored by
+// signing in.
+const accountGracePeriod = 30 * 24 * time.Hour
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: main [flags] [migrate up|down|status]")
+		flag.PrintDefaults()
+	}
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	//the standard logger writes through this one too
+	slog.SetDefault(logging.New(os.Stderr, cfg.Log.Level, cfg.Log.Format))
+
+	//deploys stop the server with SIGTERM, a terminal with SIGINT
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	//the database may still be starting up along with the server
+	repository, err := repositories.NewMySqlRepository(ctx, cfg.Database.DSN, cfg.RepositoryOptions())
+	if err != nil {
+		fatal("cannot connect to database", err)
+	}
+	if flag.Arg(0) == "migrate" {
+		runMigrate(repository, flag.Args()[1:])
+		return
+	}
+	//the schema is only changed by `migrate up`, never by the server
+	pending, err := repository.PendingMigrations(ctx)
+	if err != nil {
+		fatal("cannot read schema version", err)
+	}
+	if pending > 0 {
+		fatal("database schema is behind, run `migrate up` first", fmt.Errorf("%d migration(s) pending", pending))
+	}
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TracingOptions())
+	if err != nil {
+		fatal("cannot set up tracing", err)
+	}
+	pool, err := repository.PoolCollector()
+	if err != nil {
+		fatal("cannot collect pool statistics", err)
+	}
+	prometheus.MustRegister(pool)
+	blobs, err := storage.NewLocalBlobStore(cfg.Media.Dir)
+	if err != nil {
+		fatal("cannot open media store", err)
+	}
+	service := services.NewUserService(repository, blobs)
+	handler := handlers.NewHandler(services.WithTracing(service))
+	health := handlers.NewHealth()
+	health.Add("database", repository.Ping)
+	health.Add("migrations", func(ctx context.Context) error {
+		pending, err := repository.PendingMigrations(ctx)
+		if err == nil && pending > 0 {
+			err = fmt.Errorf("%d migration(s) pending", pending)
+		}
+		return err
 	})
 
-	h := c.Handler(r)
-	//start server
-	err := http.ListenAndServe(":8000", h)
+	//the workers stop on the signal too, while the requests drain
+	workers := startWorkers(ctx, service, cfg, health)
+	serveErr := serve(ctx, cfg, setUpRoutes(handler, health, cfg))
+	if serveErr != nil {
+		slog.Error("server failed", "error", serveErr)
+	}
+	//when the server failed the workers have not been stopped yet
+	stop()
+	waitCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if !wait(waitCtx, workers) {
+		slog.Warn("background work did not stop in time")
+	}
+	err = repository.Close()
+	if err != nil {
+		slog.Error("cannot close database", "error", err)
+	}
+	//the spans of the last requests are still to be sent
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancelFlush()
+	err = shutdownTracing(flushCtx)
 	if err != nil {
-		log.Fatal("cant start server")
+		slog.Error("cannot flush traces", "error", err)
 	}
+	slog.Info("server stopped")
+	if serveErr != nil {
+		os.Exit(1)
+	}
+}
 
+// fatal logs why the server cannot start and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
 
-func main() {
-	const dsn = "root:@tcp(127.0.0.1:3306)/demodb?parseTime=true"
+// worker is a background job of the server.
+type worker interface {
+	Run(ctx context.Context)
+	Heartbeat() *services.Heartbeat
+}
 
-	repository := repositories.NewMySqlRepository(dsn)
-	service := services.NewUserService(repository)
-	handler := handlers.NewHandler(service)
+// startWorkers starts the background workers that are switched on and adds
+// their heartbeats to the readiness checks. They run until ctx is cancelled,
+// the wait group tells when they are done.
+func startWorkers(ctx context.Context, service *services.UserService, cfg *config.Config, health *handlers.Health) *sync.WaitGroup {
+	var workers sync.WaitGroup
+	start := func(name string, w worker) {
+		health.Add("worker."+name, w.Heartbeat().Check)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			w.Run(ctx)
+		}()
+	}
+	//publish scheduled tweets in the background
+	if cfg.Features.Scheduler {
+		start("scheduler", services.NewScheduler(service, 30*time.Second))
+	}
+	//keep follow suggestions fresh in the background
+	if cfg.Features.Suggestions {
+		start("suggestions", services.NewSuggestionWorker(service, time.Minute))
+	}
+	//delete accounts whose grace period is over
+	if cfg.Features.AccountPurge {
+		start("accountpurge", services.NewAccountPurger(service, time.Hour, accountGracePeriod))
+	}
+	//build requested data exports and remove expired ones
+	if cfg.Features.DataExports {
+		start("dataexports", services.NewDataExporter(service, time.Minute))
+	}
+	return &workers
+}
 
-	setUpRoutes(handler)
+// wait waits for the group until ctx is done and reports whether it finished.
+func wait(ctx context.Context, group *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"example/layered-architecture/repositories"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// runMigrate implements `migrate up|down|status`.
+func runMigrate(repository *repositories.MySQLRepository, args []string) {
+	if len(args) != 1 {
+		log.Fatal("usage: migrate up|down|status")
+	}
+	var err error
+	switch args[0] {
+	case "up":
+		err = repository.MigrateUp()
+	case "down":
+		err = repository.MigrateDown()
+	case "status":
+		err = printMigrationStatus(repository)
+	default:
+		log.Fatal("usage: migrate up|down|status")
+	}
+	if err != nil {
+		log.Fatalf("migrate %s: %v", args[0], err)
+	}
+}
+
+func printMigrationStatus(repository *repositories.MySQLRepository) error {
+	status, err := repository.MigrationStatus()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+	for _, entry := range status {
+		applied := "pending"
+		if entry.AppliedAt != nil {
+			applied = entry.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", entry.Version, entry.Name, applied)
+	}
+	return w.Flush()
+}
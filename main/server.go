@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"example/layered-architecture/config"
+	"log/slog"
+	"net"
+	"net/http"
+)
+
+// serve answers requests with h until ctx is cancelled, then stops accepting
+// connections and lets the requests in flight finish. Requests still running
+// after the shutdown timeout, long downloads for instance, have their
+// context cancelled and their connections closed.
+func serve(ctx context.Context, cfg *config.Config, h http.Handler) error {
+	//the requests' contexts outlive ctx, they are only cancelled when
+	//draining takes too long
+	base, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           h,
+		ReadHeaderTimeout: cfg.Server.ReadTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		BaseContext:       func(net.Listener) context.Context { return base },
+		ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+	}
+
+	failed := make(chan error, 1)
+	go func() {
+		var err error
+		if cfg.Server.TLS.Enabled() {
+			err = server.ListenAndServeTLS(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		failed <- err
+	}()
+	slog.Info("listening", "addr", cfg.Server.Addr, "tls", cfg.Server.TLS.Enabled())
+
+	select {
+	case err := <-failed:
+		return err
+	case <-ctx.Done():
+	}
+	slog.Info("shutting down, draining requests", "timeout", cfg.Server.ShutdownTimeout.String())
+	drainCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	err := server.Shutdown(drainCtx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		slog.Warn("requests did not finish in time, closing their connections")
+		cancelRequests()
+		err = server.Close()
+	}
+	return err
+}
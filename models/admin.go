@@ -0,0 +1,18 @@
+package models
+
+// Stats are the row counts reported by the admin tool.
+type Stats struct {
+	Users            int64 `json:"users"`
+	DeactivatedUsers int64 `json:"deactivatedusers"`
+	DisabledUsers    int64 `json:"disabledusers"`
+	Tweets           int64 `json:"tweets"`
+	Follows          int64 `json:"follows"`
+	Media            int64 `json:"media"`
+}
+
+// FollowRepair counts the self-follows that were found. They are removed
+// unless DryRun is set.
+type FollowRepair struct {
+	SelfFollows int64 `json:"selffollows"`
+	DryRun      bool  `json:"dryrun"`
+}
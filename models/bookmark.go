@@ -0,0 +1,24 @@
+package models
+
+import "gorm.io/gorm"
+
+// Bookmark is private to the user who made it, it is never shown to others.
+type Bookmark struct {
+	gorm.Model
+	UserName string `json:"name" gorm:"size:191;uniqueIndex:idx_bookmark_user_tweet"`
+	TweetID  uint   `json:"tweetid" gorm:"uniqueIndex:idx_bookmark_user_tweet"`
+	FolderID *uint  `json:"folderid"`
+	Tweet    *Tweet `json:"tweet,omitempty"`
+}
+
+type BookmarkFolder struct {
+	gorm.Model
+	UserName string `json:"name"`
+	Title    string `json:"title"`
+}
+
+// BookmarkPage is one page of bookmarks. NextCursor is empty on the last page.
+type BookmarkPage struct {
+	Bookmarks  []Bookmark `json:"bookmarks"`
+	NextCursor string     `json:"nextcursor"`
+}
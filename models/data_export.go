@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+)
+
+// DataExport is an archive of everything stored about a user. It is built in
+// the background and can be downloaded through DownloadURL until ExpiresAt.
+type DataExport struct {
+	gorm.Model
+	UserName     string     `json:"name" gorm:"size:191;index"`
+	Status       string     `json:"status"`
+	Error        string     `json:"error,omitempty"`
+	ExpiresAt    *time.Time `json:"expiresat,omitempty"`
+	DownloadURL  string     `json:"downloadurl,omitempty" gorm:"-"`
+	Token        string     `json:"-" gorm:"size:191;index"`
+	BlobKey      string     `json:"-"`
+	ClaimedBy    string     `json:"-"`
+	ClaimedUntil *time.Time `json:"-"`
+}
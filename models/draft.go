@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Draft is a tweet that has not been published yet. Drafts with a PublishAt
+// time are scheduled and get published by the scheduler once it has passed.
+type Draft struct {
+	gorm.Model
+	UserName     string     `json:"name"`
+	Content      string     `json:"content"`
+	PublishAt    *time.Time `json:"publishat"`
+	PublishedAt  *time.Time `json:"publishedat"`
+	TweetID      *uint      `json:"tweetid"`
+	Error        string     `json:"error,omitempty"`
+	ClaimedBy    string     `json:"-"`
+	ClaimedUntil *time.Time `json:"-"`
+}
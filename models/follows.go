@@ -2,8 +2,13 @@ package models
 
 import "gorm.io/gorm"
 
+// Follows is an edge of the follow graph. The user names are read from the
+// users table, the edge itself references users by id. There is at most one
+// edge per pair of users.
 type Follows struct {
 	gorm.Model
-	SourceUser string `json:"sourceuser"`
-	TargetUser string `json:"targetuser"`
+	SourceUser   string `json:"sourceuser" gorm:"->;-:migration"`
+	TargetUser   string `json:"targetuser" gorm:"->;-:migration"`
+	SourceUserID uint   `json:"-" gorm:"index;uniqueIndex:idx_follows_source_target"`
+	TargetUserID uint   `json:"-" gorm:"index;uniqueIndex:idx_follows_source_target"`
 }
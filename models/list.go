@@ -0,0 +1,25 @@
+package models
+
+import "gorm.io/gorm"
+
+// List is a curated set of users whose tweets make up the list timeline.
+// Private lists are only visible to their owner.
+type List struct {
+	gorm.Model
+	OwnerName   string `json:"owner"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+}
+
+type ListMember struct {
+	gorm.Model
+	ListID   uint   `json:"listid" gorm:"uniqueIndex:idx_list_member"`
+	UserName string `json:"name" gorm:"size:191;uniqueIndex:idx_list_member"`
+}
+
+type ListSubscription struct {
+	gorm.Model
+	ListID   uint   `json:"listid" gorm:"uniqueIndex:idx_list_subscriber"`
+	UserName string `json:"name" gorm:"size:191;uniqueIndex:idx_list_subscriber"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "gorm.io/gorm"
+
+type Media struct {
+	gorm.Model
+	UserName     string `json:"name"`
+	TweetID      *uint  `json:"tweetid"`
+	MimeType     string `json:"mimetype"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	BlobKey      string `json:"-"`
+	ThumbnailKey string `json:"-"`
+}
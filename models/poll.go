@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Poll is attached to a tweet. Vote counts are only filled in for users who
+// voted, or for everybody once the poll has closed.
+type Poll struct {
+	gorm.Model
+	TweetID         uint         `json:"tweetid" gorm:"uniqueIndex"`
+	ClosesAt        time.Time    `json:"closesat"`
+	Options         []PollOption `json:"options"`
+	DurationMinutes int          `json:"durationminutes" gorm:"-"`
+	Closed          bool         `json:"closed" gorm:"-"`
+	VotedOption     *uint        `json:"votedoption,omitempty" gorm:"-"`
+	TotalVotes      *int         `json:"totalvotes,omitempty" gorm:"-"`
+}
+
+type PollOption struct {
+	ID     uint   `json:"id" gorm:"primarykey"`
+	PollID uint   `json:"-"`
+	Text   string `json:"text"`
+	Votes  *int   `json:"votes,omitempty" gorm:"-"`
+}
+
+// PollVote is unique per poll and user, which is what keeps concurrent
+// requests from voting twice.
+type PollVote struct {
+	gorm.Model
+	PollID   uint   `json:"pollid" gorm:"uniqueIndex:idx_poll_voter"`
+	OptionID uint   `json:"optionid"`
+	UserName string `json:"name" gorm:"size:191;uniqueIndex:idx_poll_voter"`
+}
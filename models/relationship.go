@@ -0,0 +1,14 @@
+package models
+
+// Relationship describes how Source relates to Target. It is computed, not
+// stored. Blocking, muting and follow requests do not exist yet, so those
+// flags are always false for now.
+type Relationship struct {
+	Source     string `json:"source"`
+	Target     string `json:"target"`
+	Following  bool   `json:"following"`
+	FollowedBy bool   `json:"followed_by"`
+	Blocking   bool   `json:"blocking"`
+	Muting     bool   `json:"muting"`
+	Requested  bool   `json:"requested"`
+}
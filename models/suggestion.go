@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Suggestion is a precomputed who-to-follow entry: Candidate is followed by
+// Mutuals of the users UserName follows.
+type Suggestion struct {
+	gorm.Model
+	UserName  string  `json:"-" gorm:"size:191;index"`
+	Candidate string  `json:"name"`
+	Mutuals   int     `json:"mutuals"`
+	Followers int     `json:"followers"`
+	Score     float64 `json:"score"`
+}
+
+// SuggestionState records when the suggestions of a user were computed and
+// whether their follows changed since.
+type SuggestionState struct {
+	gorm.Model
+	UserName   string `gorm:"size:191;uniqueIndex"`
+	ComputedAt time.Time
+	Stale      bool
+}
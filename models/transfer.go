@@ -0,0 +1,16 @@
+package models
+
+// ImportError is a row that could not be imported. Line is where the row
+// starts in the input.
+type ImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportReport sums up an import. Failed counts every rejected row, Errors
+// only lists the first ones.
+type ImportReport struct {
+	Imported int           `json:"imported"`
+	Failed   int           `json:"failed"`
+	Errors   []ImportError `json:"errors"`
+}
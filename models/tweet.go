@@ -4,6 +4,16 @@ import "gorm.io/gorm"
 
 type Tweet struct {
 	gorm.Model
-	UserName string `json:"name"`
-	Content  string `json:"content"`
+	// UserName is the author's current handle, read from the users table.
+	UserName string  `json:"name" gorm:"->;-:migration"`
+	UserID   uint    `json:"-" gorm:"index"`
+	Content  string  `json:"content"`
+	MediaIDs []uint  `json:"mediaids,omitempty" gorm:"-"`
+	Media    []Media `json:"media,omitempty"`
+	Poll     *Poll   `json:"poll,omitempty"`
+	// Pinned marks the tweet its author pinned to their profile.
+	Pinned bool `json:"pinned" gorm:"-"`
+	// DraftID is set on tweets published from a scheduled draft; the unique
+	// index makes sure a draft can never be published twice.
+	DraftID *uint `json:"-" gorm:"uniqueIndex"`
 }
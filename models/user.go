@@ -1,9 +1,20 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	gorm.Model
-	Name     string `json:"name" gorm:"unique"`
-	Password string `json:"password"`
+	Name          string `json:"name" gorm:"unique"`
+	Password      string `json:"password"`
+	PinnedTweetID *uint  `json:"pinnedtweetid"`
+	// DeactivatedAt is set while an account waits to be deleted. Signing in
+	// again within the grace period reactivates it.
+	DeactivatedAt *time.Time `json:"deactivatedat,omitempty"`
+	// DisabledAt is set when an admin locks the account. Unlike a
+	// deactivation, only an admin can undo it.
+	DisabledAt *time.Time `json:"disabledat,omitempty"`
 }
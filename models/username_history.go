@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UsernameHistory remembers a handle a user gave up. Until ReleasedAt the old
+// handle redirects to the user and nobody else can take it.
+type UsernameHistory struct {
+	gorm.Model
+	UserID     uint      `json:"-" gorm:"index"`
+	OldName    string    `json:"oldname" gorm:"size:191;index"`
+	ReleasedAt time.Time `json:"releasedat"`
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Errors the repository reports, usually wrapped with what was not found or
+// not allowed. The service layer turns them into errors for its callers.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("already exists")
+	ErrInvalid      = errors.New("invalid")
+	ErrUnauthorized = errors.New("wrong name or password")
+)
+
+// errDuplicateKey is the MySQL error number for a row that breaks a unique
+// index.
+const errDuplicateKey = 1062
+
+// errNoSuchTable is the MySQL error number for a table that does not exist.
+const errNoSuchTable = 1146
+
+// conflict reports a unique index violation as ErrConflict, described by what.
+// Other errors are returned as they are.
+func conflict(err error, what string) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateKey {
+		return fmt.Errorf("%s: %w", what, ErrConflict)
+	}
+	return err
+}
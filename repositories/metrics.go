@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+var queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "db_query_duration_seconds",
+	Help:    "Duration of database statements by repository method and kind of statement.",
+	Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+}, []string{"method", "operation", "result"})
+
+// methodPrefix is how the methods of MySQLRepository show up on the stack.
+var methodPrefix = reflect.TypeOf(MySQLRepository{}).PkgPath() + ".(*MySQLRepository)."
+
+const queryStartKey = "metrics:start"
+
+// instrument times and traces every statement run through db. The
+// statements are told apart by the repository method that runs them, which
+// is looked up on the stack so that the methods need not name themselves.
+func instrument(db *gorm.DB) error {
+	err := around(db, "metrics", func(string) func(*gorm.DB) { return startTimer }, observeDuration)
+	if err != nil {
+		return err
+	}
+	return around(db, "tracing", startSpan, endSpan)
+}
+
+// around registers callbacks that run before and after every kind of
+// statement, made for the kind by before and after.
+func around(db *gorm.DB, name string, before func(operation string) func(*gorm.DB), after func(operation string) func(*gorm.DB)) error {
+	callback := db.Callback()
+	for operation, register := range map[string][2]func(name string, fn func(*gorm.DB)) error{
+		"create": {callback.Create().Before("gorm:create").Register, callback.Create().After("gorm:create").Register},
+		"query":  {callback.Query().Before("gorm:query").Register, callback.Query().After("gorm:query").Register},
+		"update": {callback.Update().Before("gorm:update").Register, callback.Update().After("gorm:update").Register},
+		"delete": {callback.Delete().Before("gorm:delete").Register, callback.Delete().After("gorm:delete").Register},
+		"row":    {callback.Row().Before("gorm:row").Register, callback.Row().After("gorm:row").Register},
+		"raw":    {callback.Raw().Before("gorm:raw").Register, callback.Raw().After("gorm:raw").Register},
+	} {
+		err := register[0](name+":before_"+operation, before(operation))
+		if err != nil {
+			return err
+		}
+		err = register[1](name+":after_"+operation, after(operation))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startTimer(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func observeDuration(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		result := "ok"
+		if db.Error != nil && db.Error != gorm.ErrRecordNotFound {
+			result = "error"
+		}
+		queryDuration.WithLabelValues(repositoryMethod(), operation, result).Observe(time.Since(value.(time.Time)).Seconds())
+	}
+}
+
+// repositoryMethod returns the name of the MySQLRepository method up the
+// stack, or "other" for statements from outside of them.
+func repositoryMethod() string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if method, ok := strings.CutPrefix(frame.Function, methodPrefix); ok {
+			//closures, such as the bodies of transactions, count for their method
+			method, _, _ = strings.Cut(method, ".")
+			return method
+		}
+		if !more {
+			return "other"
+		}
+	}
+}
+
+// PoolCollector exports the statistics of the connection pool, such as the
+// connections in use and the time spent waiting for one.
+func (repository *MySQLRepository) PoolCollector() (prometheus.Collector, error) {
+	sqlDB, err := repository.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return collectors.NewDBStatsCollector(sqlDB, "mysql"), nil
+}
@@ -0,0 +1,360 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// schemaMigration records a migration that has been applied to the database.
+type schemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// MigrationStatus tells whether a migration has been applied and when.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+type migration struct {
+	version int
+	name    string
+	up      func(db *gorm.DB) error
+	down    func(db *gorm.DB) error
+}
+
+// migrations are applied in order and never edited once released: schema
+// changes, including changes to the models, get a new migration at the end.
+// MySQL commits DDL statements right away, so a migration cannot run in a
+// transaction; each step checks the schema first so that a failed migration
+// can simply be run again.
+var migrations = []migration{
+	{version: 1, name: "baseline", up: createBaseline, down: dropBaseline},
+	{version: 2, name: "user id foreign keys", up: migrateUserIDForeignKeys, down: revertUserIDForeignKeys},
+	{version: 3, name: "disabled users", up: addDisabledAt, down: dropDisabledAt},
+	{version: 4, name: "data exports", up: createDataExports, down: dropDataExports},
+	{version: 5, name: "unique follows", up: addUniqueFollows, down: dropUniqueFollows},
+}
+
+// appliedMigrations returns the versions recorded in the schema table. Until
+// MigrateUp has created the table nothing has been applied.
+func appliedMigrations(db *gorm.DB) (map[int]schemaMigration, error) {
+	var rows []schemaMigration
+	err := db.Find(&rows).Error
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == errNoSuchTable {
+		return map[int]schemaMigration{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// MigrateUp applies every pending migration.
+func (repository *MySQLRepository) MigrateUp() error {
+	err := repository.db.AutoMigrate(&schemaMigration{})
+	if err != nil {
+		return err
+	}
+	applied, err := appliedMigrations(repository.db)
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if _, ok := applied[m.version]; ok {
+			continue
+		}
+		err = m.up(repository.db)
+		if err != nil {
+			return err
+		}
+		err = repository.db.Create(&schemaMigration{Version: m.version, Name: m.name, AppliedAt: time.Now()}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MigrateDown reverts the latest applied migration.
+func (repository *MySQLRepository) MigrateDown() error {
+	applied, err := appliedMigrations(repository.db)
+	if err != nil {
+		return err
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if _, ok := applied[m.version]; !ok {
+			continue
+		}
+		err = m.down(repository.db)
+		if err != nil {
+			return err
+		}
+		return repository.db.Delete(&schemaMigration{}, m.version).Error
+	}
+	return errors.New("no migration to revert")
+}
+
+// MigrationStatus lists all migrations, AppliedAt is nil for pending ones.
+func (repository *MySQLRepository) MigrationStatus() ([]MigrationStatus, error) {
+	applied, err := appliedMigrations(repository.db)
+	if err != nil {
+		return nil, err
+	}
+	status := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		entry := MigrationStatus{Version: m.version, Name: m.name}
+		if row, ok := applied[m.version]; ok {
+			appliedAt := row.AppliedAt
+			entry.AppliedAt = &appliedAt
+		}
+		status = append(status, entry)
+	}
+	return status, nil
+}
+
+// PendingMigrations counts the migrations that have not been applied yet.
+func (repository *MySQLRepository) PendingMigrations(ctx context.Context) (int, error) {
+	applied, err := appliedMigrations(repository.db.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	pending := 0
+	for _, m := range migrations {
+		if _, ok := applied[m.version]; !ok {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+var userIDConstraints = []struct{ table, name, column string }{
+	{"tweets", "fk_tweets_user", "user_id"},
+	{"follows", "fk_follows_source_user", "source_user_id"},
+	{"follows", "fk_follows_target_user", "target_user_id"},
+}
+
+// userNameColumns are the columns the ids replace. The migrator looks them up
+// on the baseline models, it cannot find columns by table name alone.
+var userNameColumns = []struct {
+	model  interface{}
+	table  string
+	field  string
+	column string
+	id     string
+}{
+	{&v1Tweet{}, "tweets", "UserName", "user_name", "user_id"},
+	{&v1Follows{}, "follows", "SourceUser", "source_user", "source_user_id"},
+	{&v1Follows{}, "follows", "TargetUser", "target_user", "target_user_id"},
+}
+
+const (
+	orphanTweets = "SELECT id FROM tweets WHERE user_id NOT IN (SELECT id FROM users)"
+	orphanPolls  = "SELECT id FROM polls WHERE tweet_id IN (" + orphanTweets + ")"
+)
+
+// userIDOrphans are the rows that point at users that do not exist, children
+// first. Media are kept and only detached from their tweet.
+var userIDOrphans = []struct {
+	table  string
+	where  string
+	detach bool
+}{
+	{"poll_votes", "poll_id IN (" + orphanPolls + ")", false},
+	{"poll_options", "poll_id IN (" + orphanPolls + ")", false},
+	{"polls", "tweet_id IN (" + orphanTweets + ")", false},
+	{"bookmarks", "tweet_id IN (" + orphanTweets + ")", false},
+	{"media", "tweet_id IN (" + orphanTweets + ")", true},
+	{"tweets", "user_id NOT IN (SELECT id FROM users)", false},
+	{"follows", "source_user_id NOT IN (SELECT id FROM users) or target_user_id NOT IN (SELECT id FROM users)", false},
+}
+
+// migrateUserIDForeignKeys moves tweets and follows from user names to user
+// ids: it backfills the ids, moves rows pointing at users that do not exist
+// to orphaned_ tables, drops the name columns and adds foreign keys.
+func migrateUserIDForeignKeys(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if migrator.HasColumn(&v1Tweet{}, "UserName") {
+		err := db.Exec("UPDATE tweets JOIN users ON BINARY users.name = tweets.user_name SET tweets.user_id = users.id WHERE tweets.user_id = 0").Error
+		if err != nil {
+			return err
+		}
+	}
+	if migrator.HasColumn(&v1Follows{}, "SourceUser") {
+		err := db.Exec("UPDATE follows JOIN users ON BINARY users.name = follows.source_user SET follows.source_user_id = users.id WHERE follows.source_user_id = 0").Error
+		if err != nil {
+			return err
+		}
+		err = db.Exec("UPDATE follows JOIN users ON BINARY users.name = follows.target_user SET follows.target_user_id = users.id WHERE follows.target_user_id = 0").Error
+		if err != nil {
+			return err
+		}
+	}
+
+	//orphans cannot satisfy the foreign keys, remove them and what hangs off
+	//them; the rows are copied to orphaned_ tables first so none are lost
+	for _, o := range userIDOrphans {
+		err := db.Exec("CREATE TABLE IF NOT EXISTS orphaned_" + o.table + " LIKE " + o.table).Error
+		if err != nil {
+			return err
+		}
+		err = db.Exec("INSERT IGNORE INTO orphaned_" + o.table + " SELECT * FROM " + o.table + " WHERE " + o.where).Error
+		if err != nil {
+			return err
+		}
+		statement := "DELETE FROM " + o.table + " WHERE " + o.where
+		if o.detach {
+			statement = "UPDATE " + o.table + " SET tweet_id = NULL WHERE " + o.where
+		}
+		result := db.Exec(statement)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			slog.Warn("removed rows of users that do not exist", "table", o.table, "rows", result.RowsAffected, "copy", "orphaned_"+o.table)
+		}
+	}
+
+	for _, c := range userNameColumns {
+		if !migrator.HasColumn(c.model, c.field) {
+			continue
+		}
+		err := migrator.DropColumn(c.model, c.field)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range userIDConstraints {
+		if migrator.HasConstraint(c.table, c.name) {
+			continue
+		}
+		err := db.Exec("ALTER TABLE " + c.table + " ADD CONSTRAINT " + c.name +
+			" FOREIGN KEY (" + c.column + ") REFERENCES users(id) ON DELETE CASCADE").Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revertUserIDForeignKeys brings the name columns back and fills them from
+// the users table. Rows removed as orphans are not restored, they stay in the
+// orphaned_ tables.
+func revertUserIDForeignKeys(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for _, c := range userIDConstraints {
+		if !migrator.HasConstraint(c.table, c.name) {
+			continue
+		}
+		err := db.Exec("ALTER TABLE " + c.table + " DROP FOREIGN KEY " + c.name).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range userNameColumns {
+		if migrator.HasColumn(c.model, c.field) {
+			continue
+		}
+		err := migrator.AddColumn(c.model, c.field)
+		if err != nil {
+			return err
+		}
+		err = db.Exec("UPDATE " + c.table + " JOIN users ON users.id = " + c.table + "." + c.id +
+			" SET " + c.table + "." + c.column + " = users.name").Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type v3User struct {
+	DisabledAt *time.Time
+}
+
+func (v3User) TableName() string { return "users" }
+
+func addDisabledAt(db *gorm.DB) error {
+	if db.Migrator().HasColumn(&v3User{}, "DisabledAt") {
+		return nil
+	}
+	return db.Migrator().AddColumn(&v3User{}, "DisabledAt")
+}
+
+func dropDisabledAt(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&v3User{}, "DisabledAt") {
+		return nil
+	}
+	return db.Migrator().DropColumn(&v3User{}, "DisabledAt")
+}
+
+type v4DataExport struct {
+	gorm.Model
+	UserName     string `gorm:"size:191;index"`
+	Status       string
+	Error        string
+	ExpiresAt    *time.Time
+	Token        string `gorm:"size:191;index"`
+	BlobKey      string
+	ClaimedBy    string
+	ClaimedUntil *time.Time
+}
+
+func (v4DataExport) TableName() string { return "data_exports" }
+
+func createDataExports(db *gorm.DB) error {
+	return db.AutoMigrate(&v4DataExport{})
+}
+
+func dropDataExports(db *gorm.DB) error {
+	return db.Migrator().DropTable(&v4DataExport{})
+}
+
+type v5Follows struct {
+	SourceUserID uint `gorm:"uniqueIndex:idx_follows_source_target"`
+	TargetUserID uint `gorm:"uniqueIndex:idx_follows_source_target"`
+}
+
+func (v5Follows) TableName() string { return "follows" }
+
+// addUniqueFollows allows a single edge per pair of users. Unfollowing now
+// deletes the edge, so soft-deleted edges are removed, and of duplicate edges
+// only the oldest is kept. Removed edges are not restored on the way down.
+func addUniqueFollows(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&v5Follows{}, "idx_follows_source_target") {
+		return nil
+	}
+	err := db.Exec("DELETE FROM follows WHERE deleted_at IS NOT NULL").Error
+	if err != nil {
+		return err
+	}
+	err = db.Exec("DELETE f FROM follows f JOIN follows older ON older.source_user_id = f.source_user_id" +
+		" and older.target_user_id = f.target_user_id and older.id < f.id").Error
+	if err != nil {
+		return err
+	}
+	return db.Migrator().CreateIndex(&v5Follows{}, "idx_follows_source_target")
+}
+
+func dropUniqueFollows(db *gorm.DB) error {
+	if !db.Migrator().HasIndex(&v5Follows{}, "idx_follows_source_target") {
+		return nil
+	}
+	return db.Migrator().DropIndex(&v5Follows{}, "idx_follows_source_target")
+}
@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The baseline migration creates the schema as it was before migrations were
+// versioned. It works on copies of the models taken at that point, so later
+// changes to the models never change what the baseline creates. Do not edit
+// these, add a migration instead.
+
+type v1User struct {
+	gorm.Model
+	Name          string `gorm:"unique"`
+	Password      string
+	PinnedTweetID *uint
+	DeactivatedAt *time.Time
+}
+
+func (v1User) TableName() string { return "users" }
+
+type v1Follows struct {
+	gorm.Model
+	SourceUser   string
+	TargetUser   string
+	SourceUserID uint `gorm:"index"`
+	TargetUserID uint `gorm:"index"`
+}
+
+func (v1Follows) TableName() string { return "follows" }
+
+type v1Tweet struct {
+	gorm.Model
+	UserName string
+	UserID   uint `gorm:"index"`
+	Content  string
+	Media    []v1Media `gorm:"foreignKey:TweetID"`
+	Poll     *v1Poll   `gorm:"foreignKey:TweetID"`
+	DraftID  *uint     `gorm:"uniqueIndex"`
+}
+
+func (v1Tweet) TableName() string { return "tweets" }
+
+type v1Poll struct {
+	gorm.Model
+	TweetID  uint `gorm:"uniqueIndex"`
+	ClosesAt time.Time
+	Options  []v1PollOption `gorm:"foreignKey:PollID"`
+}
+
+func (v1Poll) TableName() string { return "polls" }
+
+type v1PollOption struct {
+	ID     uint `gorm:"primarykey"`
+	PollID uint
+	Text   string
+}
+
+func (v1PollOption) TableName() string { return "poll_options" }
+
+type v1PollVote struct {
+	gorm.Model
+	PollID   uint `gorm:"uniqueIndex:idx_poll_voter"`
+	OptionID uint
+	UserName string `gorm:"size:191;uniqueIndex:idx_poll_voter"`
+}
+
+func (v1PollVote) TableName() string { return "poll_votes" }
+
+type v1Media struct {
+	gorm.Model
+	UserName     string
+	TweetID      *uint
+	MimeType     string
+	Size         int64
+	Width        int
+	Height       int
+	BlobKey      string
+	ThumbnailKey string
+}
+
+func (v1Media) TableName() string { return "media" }
+
+type v1Draft struct {
+	gorm.Model
+	UserName     string
+	Content      string
+	PublishAt    *time.Time
+	PublishedAt  *time.Time
+	TweetID      *uint
+	Error        string
+	ClaimedBy    string
+	ClaimedUntil *time.Time
+}
+
+func (v1Draft) TableName() string { return "drafts" }
+
+type v1Bookmark struct {
+	gorm.Model
+	UserName string `gorm:"size:191;uniqueIndex:idx_bookmark_user_tweet"`
+	TweetID  uint   `gorm:"uniqueIndex:idx_bookmark_user_tweet"`
+	FolderID *uint
+	Tweet    *v1Tweet `gorm:"foreignKey:TweetID"`
+}
+
+func (v1Bookmark) TableName() string { return "bookmarks" }
+
+type v1BookmarkFolder struct {
+	gorm.Model
+	UserName string
+	Title    string
+}
+
+func (v1BookmarkFolder) TableName() string { return "bookmark_folders" }
+
+type v1List struct {
+	gorm.Model
+	OwnerName   string
+	Title       string
+	Description string
+	Private     bool
+}
+
+func (v1List) TableName() string { return "lists" }
+
+type v1ListMember struct {
+	gorm.Model
+	ListID   uint   `gorm:"uniqueIndex:idx_list_member"`
+	UserName string `gorm:"size:191;uniqueIndex:idx_list_member"`
+}
+
+func (v1ListMember) TableName() string { return "list_members" }
+
+type v1ListSubscription struct {
+	gorm.Model
+	ListID   uint   `gorm:"uniqueIndex:idx_list_subscriber"`
+	UserName string `gorm:"size:191;uniqueIndex:idx_list_subscriber"`
+}
+
+func (v1ListSubscription) TableName() string { return "list_subscriptions" }
+
+type v1Suggestion struct {
+	gorm.Model
+	UserName  string `gorm:"size:191;index"`
+	Candidate string
+	Mutuals   int
+	Followers int
+	Score     float64
+}
+
+func (v1Suggestion) TableName() string { return "suggestions" }
+
+type v1SuggestionState struct {
+	gorm.Model
+	UserName   string `gorm:"size:191;uniqueIndex"`
+	ComputedAt time.Time
+	Stale      bool
+}
+
+func (v1SuggestionState) TableName() string { return "suggestion_states" }
+
+type v1UsernameHistory struct {
+	gorm.Model
+	UserID     uint   `gorm:"index"`
+	OldName    string `gorm:"size:191;index"`
+	ReleasedAt time.Time
+}
+
+func (v1UsernameHistory) TableName() string { return "username_histories" }
+
+// v1Tables are in creation order, dropping goes the other way round.
+var v1Tables = []interface{}{
+	&v1User{},
+	&v1Follows{},
+	&v1Tweet{},
+	&v1Poll{}, &v1PollOption{}, &v1PollVote{},
+	&v1Media{},
+	&v1Draft{},
+	&v1Bookmark{}, &v1BookmarkFolder{},
+	&v1List{}, &v1ListMember{}, &v1ListSubscription{},
+	&v1Suggestion{}, &v1SuggestionState{},
+	&v1UsernameHistory{},
+}
+
+// createBaseline also brings databases created before migrations were
+// versioned up to date, since AutoMigrate leaves existing tables alone.
+func createBaseline(db *gorm.DB) error {
+	return db.AutoMigrate(v1Tables...)
+}
+
+func dropBaseline(db *gorm.DB) error {
+	for i := len(v1Tables) - 1; i >= 0; i-- {
+		err := db.Migrator().DropTable(v1Tables[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectSchemaCount answers the next schema lookup of the migrator, such as
+// HasColumn, with count.
+func expectSchemaCount(mock sqlmock.Sqlmock, table string, count int, args ...driver.Value) {
+	mock.ExpectQuery("SELECT DATABASE()").WillReturnRows(sqlmock.NewRows([]string{"DATABASE()"}).AddRow("demodb"))
+	mock.ExpectQuery("SELECT SCHEMA_NAME from Information_schema.SCHEMATA").
+		WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME"}).AddRow("demodb"))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM INFORMATION_SCHEMA." + table).
+		WithArgs(append([]driver.Value{"demodb"}, args...)...).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(count))
+}
+
+func TestMigrateUserIDForeignKeys(t *testing.T) {
+	type testCase struct {
+		name     string
+		migrated bool
+	}
+	testCases := []testCase{{name: "backfills ids from names",
+		migrated: false},
+		{name: "run again",
+			migrated: true}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			repository, mock := newMockRepository(t)
+			nameColumns := 1
+			if test.migrated {
+				nameColumns = 0
+			}
+			expectSchemaCount(mock, "columns", nameColumns, "tweets", "user_name")
+			if !test.migrated {
+				mock.ExpectExec("UPDATE tweets JOIN users ON BINARY users.name = tweets.user_name SET tweets.user_id = users.id WHERE tweets.user_id = 0").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			}
+			expectSchemaCount(mock, "columns", nameColumns, "follows", "source_user")
+			if !test.migrated {
+				mock.ExpectExec("UPDATE follows JOIN users ON BINARY users.name = follows.source_user SET follows.source_user_id = users.id WHERE follows.source_user_id = 0").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE follows JOIN users ON BINARY users.name = follows.target_user SET follows.target_user_id = users.id WHERE follows.target_user_id = 0").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+			//rows of users that do not exist, and what hangs off them, are
+			//copied before they are removed
+			for _, table := range []string{"poll_votes", "poll_options", "polls", "bookmarks", "media", "tweets", "follows"} {
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS orphaned_" + table + " LIKE " + table + "$").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT IGNORE INTO orphaned_" + table + " SELECT \\* FROM " + table + " WHERE .*NOT IN \\(SELECT id FROM users\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("(DELETE FROM|UPDATE) " + table + " .*NOT IN \\(SELECT id FROM users\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			for _, c := range userNameColumns {
+				expectSchemaCount(mock, "columns", nameColumns, c.table, c.column)
+				if !test.migrated {
+					mock.ExpectExec("ALTER TABLE `" + c.table + "` DROP COLUMN `" + c.column + "`").WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+			}
+			for _, c := range userIDConstraints {
+				if test.migrated {
+					expectSchemaCount(mock, "table_constraints", 1, c.table, c.name)
+					continue
+				}
+				expectSchemaCount(mock, "table_constraints", 0, c.table, c.name)
+				mock.ExpectExec("ALTER TABLE " + c.table + " ADD CONSTRAINT " + c.name + " FOREIGN KEY \\(" + c.column + "\\) REFERENCES users\\(id\\)").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+
+			err := migrateUserIDForeignKeys(repository.db)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMigrationVersions(t *testing.T) {
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.version, "migrations are numbered in the order they are applied")
+	}
+}
+
+func TestPendingMigrations(t *testing.T) {
+	type testCase struct {
+		name     string
+		applied  []int
+		expected int
+	}
+	testCases := []testCase{{name: "no schema table",
+		applied:  nil,
+		expected: len(migrations)},
+		{name: "behind",
+			applied:  []int{1, 2},
+			expected: len(migrations) - 2}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			repository, mock := newMockRepository(t)
+			//a plain select, the table is left to MigrateUp
+			query := mock.ExpectQuery("SELECT \\* FROM `schema_migrations`$")
+			if test.applied == nil {
+				query.WillReturnError(&mysql.MySQLError{Number: 1146, Message: "Table 'demodb.schema_migrations' doesn't exist"})
+			} else {
+				rows := sqlmock.NewRows([]string{"version", "name"})
+				for _, version := range test.applied {
+					rows.AddRow(version, migrations[version-1].name)
+				}
+				query.WillReturnRows(rows)
+			}
+
+			pending, err := repository.PendingMigrations(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, pending)
+		})
+	}
+}
@@ -5,8 +5,10 @@
 package repositories
 
 import (
+	context "context"
 	models "example/layered-architecture/models"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,145 +36,1147 @@ func (m *MockRepositoryInterface) EXPECT() *MockRepositoryInterfaceMockRecorder
 	return m.recorder
 }
 
+// AddBookmark mocks base method.
+func (m *MockRepositoryInterface) AddBookmark(arg0 context.Context, arg1 *models.Bookmark) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookmark", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBookmark indicates an expected call of AddBookmark.
+func (mr *MockRepositoryInterfaceMockRecorder) AddBookmark(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookmark", reflect.TypeOf((*MockRepositoryInterface)(nil).AddBookmark), arg0, arg1)
+}
+
+// AddBookmarkFolder mocks base method.
+func (m *MockRepositoryInterface) AddBookmarkFolder(arg0 context.Context, arg1 *models.BookmarkFolder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookmarkFolder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBookmarkFolder indicates an expected call of AddBookmarkFolder.
+func (mr *MockRepositoryInterfaceMockRecorder) AddBookmarkFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookmarkFolder", reflect.TypeOf((*MockRepositoryInterface)(nil).AddBookmarkFolder), arg0, arg1)
+}
+
+// AddDataExport mocks base method.
+func (m *MockRepositoryInterface) AddDataExport(arg0 context.Context, arg1 *models.DataExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDataExport", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDataExport indicates an expected call of AddDataExport.
+func (mr *MockRepositoryInterfaceMockRecorder) AddDataExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDataExport", reflect.TypeOf((*MockRepositoryInterface)(nil).AddDataExport), arg0, arg1)
+}
+
+// AddDraft mocks base method.
+func (m *MockRepositoryInterface) AddDraft(arg0 context.Context, arg1 *models.Draft) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDraft", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDraft indicates an expected call of AddDraft.
+func (mr *MockRepositoryInterfaceMockRecorder) AddDraft(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDraft", reflect.TypeOf((*MockRepositoryInterface)(nil).AddDraft), arg0, arg1)
+}
+
 // AddFollowee mocks base method.
-func (m *MockRepositoryInterface) AddFollowee(arg0 *models.Follows) error {
+func (m *MockRepositoryInterface) AddFollowee(arg0 context.Context, arg1 *models.Follows) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddFollowee", arg0)
+	ret := m.ctrl.Call(m, "AddFollowee", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddFollowee indicates an expected call of AddFollowee.
-func (mr *MockRepositoryInterfaceMockRecorder) AddFollowee(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryInterfaceMockRecorder) AddFollowee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFollowee", reflect.TypeOf((*MockRepositoryInterface)(nil).AddFollowee), arg0, arg1)
+}
+
+// AddList mocks base method.
+func (m *MockRepositoryInterface) AddList(arg0 context.Context, arg1 *models.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddList indicates an expected call of AddList.
+func (mr *MockRepositoryInterfaceMockRecorder) AddList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddList", reflect.TypeOf((*MockRepositoryInterface)(nil).AddList), arg0, arg1)
+}
+
+// AddListMember mocks base method.
+func (m *MockRepositoryInterface) AddListMember(arg0 context.Context, arg1 *models.ListMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddListMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddListMember indicates an expected call of AddListMember.
+func (mr *MockRepositoryInterfaceMockRecorder) AddListMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddListMember", reflect.TypeOf((*MockRepositoryInterface)(nil).AddListMember), arg0, arg1)
+}
+
+// AddMedia mocks base method.
+func (m *MockRepositoryInterface) AddMedia(arg0 context.Context, arg1 *models.Media) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMedia", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMedia indicates an expected call of AddMedia.
+func (mr *MockRepositoryInterfaceMockRecorder) AddMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMedia", reflect.TypeOf((*MockRepositoryInterface)(nil).AddMedia), arg0, arg1)
+}
+
+// AddPollVote mocks base method.
+func (m *MockRepositoryInterface) AddPollVote(arg0 context.Context, arg1 *models.PollVote, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPollVote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPollVote indicates an expected call of AddPollVote.
+func (mr *MockRepositoryInterfaceMockRecorder) AddPollVote(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFollowee", reflect.TypeOf((*MockRepositoryInterface)(nil).AddFollowee), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPollVote", reflect.TypeOf((*MockRepositoryInterface)(nil).AddPollVote), arg0, arg1, arg2)
 }
 
 // AddTweet mocks base method.
-func (m *MockRepositoryInterface) AddTweet(arg0 *models.Tweet) error {
+func (m *MockRepositoryInterface) AddTweet(arg0 context.Context, arg1 *models.Tweet) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTweet", arg0)
+	ret := m.ctrl.Call(m, "AddTweet", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddTweet indicates an expected call of AddTweet.
-func (mr *MockRepositoryInterfaceMockRecorder) AddTweet(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryInterfaceMockRecorder) AddTweet(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTweet", reflect.TypeOf((*MockRepositoryInterface)(nil).AddTweet), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTweet", reflect.TypeOf((*MockRepositoryInterface)(nil).AddTweet), arg0, arg1)
 }
 
 // AddUser mocks base method.
-func (m *MockRepositoryInterface) AddUser(arg0 *models.User) error {
+func (m *MockRepositoryInterface) AddUser(arg0 context.Context, arg1 *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUser", arg0)
+	ret := m.ctrl.Call(m, "AddUser", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddUser indicates an expected call of AddUser.
-func (mr *MockRepositoryInterfaceMockRecorder) AddUser(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryInterfaceMockRecorder) AddUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockRepositoryInterface)(nil).AddUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockRepositoryInterface)(nil).AddUser), arg0, arg1)
 }
 
-// CheckFollowing mocks base method.
-func (m *MockRepositoryInterface) CheckFollowing(arg0, arg1 string) error {
+// ClaimDueDrafts mocks base method.
+func (m *MockRepositoryInterface) ClaimDueDrafts(arg0 context.Context, arg1 string, arg2 time.Time, arg3 time.Duration, arg4 int) (*[]models.Draft, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckFollowing", arg0, arg1)
+	ret := m.ctrl.Call(m, "ClaimDueDrafts", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*[]models.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDrafts indicates an expected call of ClaimDueDrafts.
+func (mr *MockRepositoryInterfaceMockRecorder) ClaimDueDrafts(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDrafts", reflect.TypeOf((*MockRepositoryInterface)(nil).ClaimDueDrafts), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ClaimPendingDataExports mocks base method.
+func (m *MockRepositoryInterface) ClaimPendingDataExports(arg0 context.Context, arg1 string, arg2 time.Time, arg3 time.Duration, arg4 int) (*[]models.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingDataExports", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*[]models.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingDataExports indicates an expected call of ClaimPendingDataExports.
+func (mr *MockRepositoryInterfaceMockRecorder) ClaimPendingDataExports(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingDataExports", reflect.TypeOf((*MockRepositoryInterface)(nil).ClaimPendingDataExports), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CountPollVotes mocks base method.
+func (m *MockRepositoryInterface) CountPollVotes(arg0 context.Context, arg1 uint) (map[uint]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPollVotes", arg0, arg1)
+	ret0, _ := ret[0].(map[uint]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPollVotes indicates an expected call of CountPollVotes.
+func (mr *MockRepositoryInterfaceMockRecorder) CountPollVotes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPollVotes", reflect.TypeOf((*MockRepositoryInterface)(nil).CountPollVotes), arg0, arg1)
+}
+
+// DeactivateUser mocks base method.
+func (m *MockRepositoryInterface) DeactivateUser(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockRepositoryInterfaceMockRecorder) DeactivateUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockRepositoryInterface)(nil).DeactivateUser), arg0, arg1, arg2)
+}
+
+// DeleteBookmark mocks base method.
+func (m *MockRepositoryInterface) DeleteBookmark(arg0 context.Context, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBookmark", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CheckFollowing indicates an expected call of CheckFollowing.
-func (mr *MockRepositoryInterfaceMockRecorder) CheckFollowing(arg0, arg1 interface{}) *gomock.Call {
+// DeleteBookmark indicates an expected call of DeleteBookmark.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteBookmark(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckFollowing", reflect.TypeOf((*MockRepositoryInterface)(nil).CheckFollowing), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBookmark", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteBookmark), arg0, arg1, arg2)
+}
+
+// DeleteBookmarkFolder mocks base method.
+func (m *MockRepositoryInterface) DeleteBookmarkFolder(arg0 context.Context, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBookmarkFolder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBookmarkFolder indicates an expected call of DeleteBookmarkFolder.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteBookmarkFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBookmarkFolder", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteBookmarkFolder), arg0, arg1, arg2)
+}
+
+// DeleteDataExport mocks base method.
+func (m *MockRepositoryInterface) DeleteDataExport(arg0 context.Context, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDataExport", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDataExport indicates an expected call of DeleteDataExport.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteDataExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDataExport", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteDataExport), arg0, arg1)
+}
+
+// DeleteDraft mocks base method.
+func (m *MockRepositoryInterface) DeleteDraft(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDraft", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDraft indicates an expected call of DeleteDraft.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteDraft(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDraft", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteDraft), arg0, arg1)
 }
 
 // DeleteFollowee mocks base method.
-func (m *MockRepositoryInterface) DeleteFollowee(arg0, arg1 string) error {
+func (m *MockRepositoryInterface) DeleteFollowee(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFollowee", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteFollowee", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFollowee indicates an expected call of DeleteFollowee.
-func (mr *MockRepositoryInterfaceMockRecorder) DeleteFollowee(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteFollowee(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFollowee", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteFollowee), arg0, arg1, arg2)
+}
+
+// DeleteList mocks base method.
+func (m *MockRepositoryInterface) DeleteList(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteList(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFollowee", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteFollowee), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteList), arg0, arg1)
+}
+
+// DeleteListMember mocks base method.
+func (m *MockRepositoryInterface) DeleteListMember(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteListMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteListMember indicates an expected call of DeleteListMember.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteListMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListMember", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteListMember), arg0, arg1, arg2)
 }
 
 // DeleteTweet mocks base method.
-func (m *MockRepositoryInterface) DeleteTweet(arg0 int) error {
+func (m *MockRepositoryInterface) DeleteTweet(arg0 context.Context, arg1 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTweet", arg0)
+	ret := m.ctrl.Call(m, "DeleteTweet", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTweet indicates an expected call of DeleteTweet.
-func (mr *MockRepositoryInterfaceMockRecorder) DeleteTweet(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteTweet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTweet", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteTweet), arg0, arg1)
+}
+
+// DisableUser mocks base method.
+func (m *MockRepositoryInterface) DisableUser(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockRepositoryInterfaceMockRecorder) DisableUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockRepositoryInterface)(nil).DisableUser), arg0, arg1, arg2)
+}
+
+// EnableUser mocks base method.
+func (m *MockRepositoryInterface) EnableUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableUser indicates an expected call of EnableUser.
+func (mr *MockRepositoryInterfaceMockRecorder) EnableUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUser", reflect.TypeOf((*MockRepositoryInterface)(nil).EnableUser), arg0, arg1)
+}
+
+// ExportFollows mocks base method.
+func (m *MockRepositoryInterface) ExportFollows(arg0 context.Context, arg1 uint, arg2 int) (*[]models.Follows, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportFollows", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]models.Follows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportFollows indicates an expected call of ExportFollows.
+func (mr *MockRepositoryInterfaceMockRecorder) ExportFollows(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportFollows", reflect.TypeOf((*MockRepositoryInterface)(nil).ExportFollows), arg0, arg1, arg2)
+}
+
+// ExportTweets mocks base method.
+func (m *MockRepositoryInterface) ExportTweets(arg0 context.Context, arg1 uint, arg2 int) (*[]models.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTweets", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]models.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTweets indicates an expected call of ExportTweets.
+func (mr *MockRepositoryInterfaceMockRecorder) ExportTweets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTweets", reflect.TypeOf((*MockRepositoryInterface)(nil).ExportTweets), arg0, arg1, arg2)
+}
+
+// ExportUsers mocks base method.
+func (m *MockRepositoryInterface) ExportUsers(arg0 context.Context, arg1 uint, arg2 int) (*[]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUsers indicates an expected call of ExportUsers.
+func (mr *MockRepositoryInterfaceMockRecorder) ExportUsers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsers", reflect.TypeOf((*MockRepositoryInterface)(nil).ExportUsers), arg0, arg1, arg2)
+}
+
+// FindFollowCandidates mocks base method.
+func (m *MockRepositoryInterface) FindFollowCandidates(arg0 context.Context, arg1 string, arg2 int) (*[]models.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFollowCandidates", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]models.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFollowCandidates indicates an expected call of FindFollowCandidates.
+func (mr *MockRepositoryInterfaceMockRecorder) FindFollowCandidates(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTweet", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteTweet), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFollowCandidates", reflect.TypeOf((*MockRepositoryInterface)(nil).FindFollowCandidates), arg0, arg1, arg2)
 }
 
 // GetAllUsers mocks base method.
-func (m *MockRepositoryInterface) GetAllUsers() (*[]models.User, error) {
+func (m *MockRepositoryInterface) GetAllUsers(arg0 context.Context) (*[]models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUsers")
+	ret := m.ctrl.Call(m, "GetAllUsers", arg0)
 	ret0, _ := ret[0].(*[]models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllUsers indicates an expected call of GetAllUsers.
-func (mr *MockRepositoryInterfaceMockRecorder) GetAllUsers() *gomock.Call {
+func (mr *MockRepositoryInterfaceMockRecorder) GetAllUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockRepositoryInterface)(nil).GetAllUsers), arg0)
+}
+
+// GetBookmarkFolders mocks base method.
+func (m *MockRepositoryInterface) GetBookmarkFolders(arg0 context.Context, arg1 string) (*[]models.BookmarkFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookmarkFolders", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.BookmarkFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookmarkFolders indicates an expected call of GetBookmarkFolders.
+func (mr *MockRepositoryInterfaceMockRecorder) GetBookmarkFolders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookmarkFolders", reflect.TypeOf((*MockRepositoryInterface)(nil).GetBookmarkFolders), arg0, arg1)
+}
+
+// GetBookmarks mocks base method.
+func (m *MockRepositoryInterface) GetBookmarks(arg0 context.Context, arg1 string, arg2 *uint, arg3 uint, arg4 int) (*[]models.Bookmark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookmarks", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*[]models.Bookmark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookmarks indicates an expected call of GetBookmarks.
+func (mr *MockRepositoryInterfaceMockRecorder) GetBookmarks(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookmarks", reflect.TypeOf((*MockRepositoryInterface)(nil).GetBookmarks), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetDataExportByToken mocks base method.
+func (m *MockRepositoryInterface) GetDataExportByToken(arg0 context.Context, arg1 string) (*models.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataExportByToken", arg0, arg1)
+	ret0, _ := ret[0].(*models.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataExportByToken indicates an expected call of GetDataExportByToken.
+func (mr *MockRepositoryInterfaceMockRecorder) GetDataExportByToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataExportByToken", reflect.TypeOf((*MockRepositoryInterface)(nil).GetDataExportByToken), arg0, arg1)
+}
+
+// GetDraft mocks base method.
+func (m *MockRepositoryInterface) GetDraft(arg0 context.Context, arg1 int) (*models.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", arg0, arg1)
+	ret0, _ := ret[0].(*models.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockRepositoryInterfaceMockRecorder) GetDraft(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockRepositoryInterface)(nil).GetDraft), arg0, arg1)
+}
+
+// GetDraftsOfUser mocks base method.
+func (m *MockRepositoryInterface) GetDraftsOfUser(arg0 context.Context, arg1 string) (*[]models.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraftsOfUser", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraftsOfUser indicates an expected call of GetDraftsOfUser.
+func (mr *MockRepositoryInterfaceMockRecorder) GetDraftsOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraftsOfUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetDraftsOfUser), arg0, arg1)
+}
+
+// GetExpiredDataExports mocks base method.
+func (m *MockRepositoryInterface) GetExpiredDataExports(arg0 context.Context, arg1 time.Time, arg2 int) (*[]models.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredDataExports", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]models.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredDataExports indicates an expected call of GetExpiredDataExports.
+func (mr *MockRepositoryInterfaceMockRecorder) GetExpiredDataExports(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockRepositoryInterface)(nil).GetAllUsers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredDataExports", reflect.TypeOf((*MockRepositoryInterface)(nil).GetExpiredDataExports), arg0, arg1, arg2)
 }
 
 // GetFolloweesOfUser mocks base method.
-func (m *MockRepositoryInterface) GetFolloweesOfUser(arg0 string) (*[]models.Follows, error) {
+func (m *MockRepositoryInterface) GetFolloweesOfUser(arg0 context.Context, arg1 string) (*[]models.Follows, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFolloweesOfUser", arg0)
+	ret := m.ctrl.Call(m, "GetFolloweesOfUser", arg0, arg1)
 	ret0, _ := ret[0].(*[]models.Follows)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFolloweesOfUser indicates an expected call of GetFolloweesOfUser.
-func (mr *MockRepositoryInterfaceMockRecorder) GetFolloweesOfUser(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryInterfaceMockRecorder) GetFolloweesOfUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolloweesOfUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetFolloweesOfUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolloweesOfUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetFolloweesOfUser), arg0, arg1)
 }
 
-// GetTweetsOfUser mocks base method.
-func (m *MockRepositoryInterface) GetTweetsOfUser(arg0 string) (*[]models.Tweet, error) {
+// GetFollowersOfUser mocks base method.
+func (m *MockRepositoryInterface) GetFollowersOfUser(arg0 context.Context, arg1 string) (*[]models.Follows, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTweetsOfUser", arg0)
-	ret0, _ := ret[0].(*[]models.Tweet)
+	ret := m.ctrl.Call(m, "GetFollowersOfUser", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.Follows)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTweetsOfUser indicates an expected call of GetTweetsOfUser.
-func (mr *MockRepositoryInterfaceMockRecorder) GetTweetsOfUser(arg0 interface{}) *gomock.Call {
+// GetFollowersOfUser indicates an expected call of GetFollowersOfUser.
+func (mr *MockRepositoryInterfaceMockRecorder) GetFollowersOfUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTweetsOfUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTweetsOfUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowersOfUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetFollowersOfUser), arg0, arg1)
 }
 
-// SignIn mocks base method.
-func (m *MockRepositoryInterface) SignIn(arg0 *models.User) error {
+// GetLatestDataExport mocks base method.
+func (m *MockRepositoryInterface) GetLatestDataExport(arg0 context.Context, arg1 string) (*models.DataExport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SignIn", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetLatestDataExport", arg0, arg1)
+	ret0, _ := ret[0].(*models.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SignIn indicates an expected call of SignIn.
-func (mr *MockRepositoryInterfaceMockRecorder) SignIn(arg0 interface{}) *gomock.Call {
+// GetLatestDataExport indicates an expected call of GetLatestDataExport.
+func (mr *MockRepositoryInterfaceMockRecorder) GetLatestDataExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestDataExport", reflect.TypeOf((*MockRepositoryInterface)(nil).GetLatestDataExport), arg0, arg1)
+}
+
+// GetList mocks base method.
+func (m *MockRepositoryInterface) GetList(arg0 context.Context, arg1 int) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0, arg1)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockRepositoryInterfaceMockRecorder) GetList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockRepositoryInterface)(nil).GetList), arg0, arg1)
+}
+
+// GetListMembers mocks base method.
+func (m *MockRepositoryInterface) GetListMembers(arg0 context.Context, arg1 int) (*[]models.ListMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMembers", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.ListMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMembers indicates an expected call of GetListMembers.
+func (mr *MockRepositoryInterfaceMockRecorder) GetListMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMembers", reflect.TypeOf((*MockRepositoryInterface)(nil).GetListMembers), arg0, arg1)
+}
+
+// GetListTimeline mocks base method.
+func (m *MockRepositoryInterface) GetListTimeline(arg0 context.Context, arg1, arg2 int) (*[]models.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListTimeline", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]models.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListTimeline indicates an expected call of GetListTimeline.
+func (mr *MockRepositoryInterfaceMockRecorder) GetListTimeline(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListTimeline", reflect.TypeOf((*MockRepositoryInterface)(nil).GetListTimeline), arg0, arg1, arg2)
+}
+
+// GetListsOfUser mocks base method.
+func (m *MockRepositoryInterface) GetListsOfUser(arg0 context.Context, arg1 string) (*[]models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListsOfUser", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListsOfUser indicates an expected call of GetListsOfUser.
+func (mr *MockRepositoryInterfaceMockRecorder) GetListsOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListsOfUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetListsOfUser), arg0, arg1)
+}
+
+// GetMedia mocks base method.
+func (m *MockRepositoryInterface) GetMedia(arg0 context.Context, arg1 int) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedia", arg0, arg1)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedia indicates an expected call of GetMedia.
+func (mr *MockRepositoryInterfaceMockRecorder) GetMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedia", reflect.TypeOf((*MockRepositoryInterface)(nil).GetMedia), arg0, arg1)
+}
+
+// GetMediaOfUser mocks base method.
+func (m *MockRepositoryInterface) GetMediaOfUser(arg0 context.Context, arg1 string) (*[]models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaOfUser", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaOfUser indicates an expected call of GetMediaOfUser.
+func (mr *MockRepositoryInterfaceMockRecorder) GetMediaOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaOfUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetMediaOfUser), arg0, arg1)
+}
+
+// GetPoll mocks base method.
+func (m *MockRepositoryInterface) GetPoll(arg0 context.Context, arg1 int) (*models.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoll", arg0, arg1)
+	ret0, _ := ret[0].(*models.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPoll indicates an expected call of GetPoll.
+func (mr *MockRepositoryInterfaceMockRecorder) GetPoll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockRepositoryInterface)(nil).GetPoll), arg0, arg1)
+}
+
+// GetPollVote mocks base method.
+func (m *MockRepositoryInterface) GetPollVote(arg0 context.Context, arg1 uint, arg2 string) (*models.PollVote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollVote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.PollVote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollVote indicates an expected call of GetPollVote.
+func (mr *MockRepositoryInterfaceMockRecorder) GetPollVote(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollVote", reflect.TypeOf((*MockRepositoryInterface)(nil).GetPollVote), arg0, arg1, arg2)
+}
+
+// GetPollVotesOfUser mocks base method.
+func (m *MockRepositoryInterface) GetPollVotesOfUser(arg0 context.Context, arg1 string) (*[]models.PollVote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollVotesOfUser", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.PollVote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollVotesOfUser indicates an expected call of GetPollVotesOfUser.
+func (mr *MockRepositoryInterfaceMockRecorder) GetPollVotesOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollVotesOfUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetPollVotesOfUser), arg0, arg1)
+}
+
+// GetRelationship mocks base method.
+func (m *MockRepositoryInterface) GetRelationship(arg0 context.Context, arg1, arg2 string) (*models.Relationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelationship", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Relationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelationship indicates an expected call of GetRelationship.
+func (mr *MockRepositoryInterfaceMockRecorder) GetRelationship(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelationship", reflect.TypeOf((*MockRepositoryInterface)(nil).GetRelationship), arg0, arg1, arg2)
+}
+
+// GetRenamedUsername mocks base method.
+func (m *MockRepositoryInterface) GetRenamedUsername(arg0 context.Context, arg1 string, arg2 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRenamedUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRenamedUsername indicates an expected call of GetRenamedUsername.
+func (mr *MockRepositoryInterfaceMockRecorder) GetRenamedUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRenamedUsername", reflect.TypeOf((*MockRepositoryInterface)(nil).GetRenamedUsername), arg0, arg1, arg2)
+}
+
+// GetStats mocks base method.
+func (m *MockRepositoryInterface) GetStats(arg0 context.Context) (*models.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0)
+	ret0, _ := ret[0].(*models.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockRepositoryInterfaceMockRecorder) GetStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockRepositoryInterface)(nil).GetStats), arg0)
+}
+
+// GetSuggestionState mocks base method.
+func (m *MockRepositoryInterface) GetSuggestionState(arg0 context.Context, arg1 string) (*models.SuggestionState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuggestionState", arg0, arg1)
+	ret0, _ := ret[0].(*models.SuggestionState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuggestionState indicates an expected call of GetSuggestionState.
+func (mr *MockRepositoryInterfaceMockRecorder) GetSuggestionState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuggestionState", reflect.TypeOf((*MockRepositoryInterface)(nil).GetSuggestionState), arg0, arg1)
+}
+
+// GetSuggestions mocks base method.
+func (m *MockRepositoryInterface) GetSuggestions(arg0 context.Context, arg1 string, arg2 int) (*[]models.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuggestions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]models.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuggestions indicates an expected call of GetSuggestions.
+func (mr *MockRepositoryInterfaceMockRecorder) GetSuggestions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuggestions", reflect.TypeOf((*MockRepositoryInterface)(nil).GetSuggestions), arg0, arg1, arg2)
+}
+
+// GetTweetsOfUser mocks base method.
+func (m *MockRepositoryInterface) GetTweetsOfUser(arg0 context.Context, arg1 string) (*[]models.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTweetsOfUser", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTweetsOfUser indicates an expected call of GetTweetsOfUser.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTweetsOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTweetsOfUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTweetsOfUser), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockRepositoryInterface) GetUser(arg0 context.Context, arg1 string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockRepositoryInterfaceMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetUser), arg0, arg1)
+}
+
+// GetUsernameHistory mocks base method.
+func (m *MockRepositoryInterface) GetUsernameHistory(arg0 context.Context, arg1 string) (*[]models.UsernameHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsernameHistory", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.UsernameHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsernameHistory indicates an expected call of GetUsernameHistory.
+func (mr *MockRepositoryInterfaceMockRecorder) GetUsernameHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsernameHistory", reflect.TypeOf((*MockRepositoryInterface)(nil).GetUsernameHistory), arg0, arg1)
+}
+
+// GetUsersToPurge mocks base method.
+func (m *MockRepositoryInterface) GetUsersToPurge(arg0 context.Context, arg1 time.Time, arg2 *models.User, arg3 int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersToPurge", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersToPurge indicates an expected call of GetUsersToPurge.
+func (mr *MockRepositoryInterfaceMockRecorder) GetUsersToPurge(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersToPurge", reflect.TypeOf((*MockRepositoryInterface)(nil).GetUsersToPurge), arg0, arg1, arg2, arg3)
+}
+
+// GetUsersWithStaleSuggestions mocks base method.
+func (m *MockRepositoryInterface) GetUsersWithStaleSuggestions(arg0 context.Context, arg1 time.Time, arg2 int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersWithStaleSuggestions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersWithStaleSuggestions indicates an expected call of GetUsersWithStaleSuggestions.
+func (mr *MockRepositoryInterfaceMockRecorder) GetUsersWithStaleSuggestions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithStaleSuggestions", reflect.TypeOf((*MockRepositoryInterface)(nil).GetUsersWithStaleSuggestions), arg0, arg1, arg2)
+}
+
+// ImportFollow mocks base method.
+func (m *MockRepositoryInterface) ImportFollow(arg0 context.Context, arg1 *models.Follows) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportFollow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportFollow indicates an expected call of ImportFollow.
+func (mr *MockRepositoryInterfaceMockRecorder) ImportFollow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportFollow", reflect.TypeOf((*MockRepositoryInterface)(nil).ImportFollow), arg0, arg1)
+}
+
+// ImportTweet mocks base method.
+func (m *MockRepositoryInterface) ImportTweet(arg0 context.Context, arg1 *models.Tweet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTweet", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportTweet indicates an expected call of ImportTweet.
+func (mr *MockRepositoryInterfaceMockRecorder) ImportTweet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTweet", reflect.TypeOf((*MockRepositoryInterface)(nil).ImportTweet), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockRepositoryInterface) ListUsers(arg0 context.Context) (*[]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0)
+	ret0, _ := ret[0].(*[]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockRepositoryInterfaceMockRecorder) ListUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockRepositoryInterface)(nil).ListUsers), arg0)
+}
+
+// MarkDataExportFailed mocks base method.
+func (m *MockRepositoryInterface) MarkDataExportFailed(arg0 context.Context, arg1 uint, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDataExportFailed", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDataExportFailed indicates an expected call of MarkDataExportFailed.
+func (mr *MockRepositoryInterfaceMockRecorder) MarkDataExportFailed(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDataExportFailed", reflect.TypeOf((*MockRepositoryInterface)(nil).MarkDataExportFailed), arg0, arg1, arg2, arg3)
+}
+
+// MarkDataExportReady mocks base method.
+func (m *MockRepositoryInterface) MarkDataExportReady(arg0 context.Context, arg1 uint, arg2, arg3 string, arg4 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDataExportReady", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDataExportReady indicates an expected call of MarkDataExportReady.
+func (mr *MockRepositoryInterfaceMockRecorder) MarkDataExportReady(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDataExportReady", reflect.TypeOf((*MockRepositoryInterface)(nil).MarkDataExportReady), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MarkDraftFailed mocks base method.
+func (m *MockRepositoryInterface) MarkDraftFailed(arg0 context.Context, arg1 uint, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDraftFailed", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDraftFailed indicates an expected call of MarkDraftFailed.
+func (mr *MockRepositoryInterfaceMockRecorder) MarkDraftFailed(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDraftFailed", reflect.TypeOf((*MockRepositoryInterface)(nil).MarkDraftFailed), arg0, arg1, arg2)
+}
+
+// MarkDraftPublished mocks base method.
+func (m *MockRepositoryInterface) MarkDraftPublished(arg0 context.Context, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDraftPublished", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDraftPublished indicates an expected call of MarkDraftPublished.
+func (mr *MockRepositoryInterfaceMockRecorder) MarkDraftPublished(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDraftPublished", reflect.TypeOf((*MockRepositoryInterface)(nil).MarkDraftPublished), arg0, arg1)
+}
+
+// MarkSuggestionsStale mocks base method.
+func (m *MockRepositoryInterface) MarkSuggestionsStale(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSuggestionsStale", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSuggestionsStale indicates an expected call of MarkSuggestionsStale.
+func (mr *MockRepositoryInterfaceMockRecorder) MarkSuggestionsStale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSuggestionsStale", reflect.TypeOf((*MockRepositoryInterface)(nil).MarkSuggestionsStale), arg0, arg1)
+}
+
+// PinTweet mocks base method.
+func (m *MockRepositoryInterface) PinTweet(arg0 context.Context, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinTweet", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinTweet indicates an expected call of PinTweet.
+func (mr *MockRepositoryInterfaceMockRecorder) PinTweet(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinTweet", reflect.TypeOf((*MockRepositoryInterface)(nil).PinTweet), arg0, arg1, arg2)
+}
+
+// PurgeUser mocks base method.
+func (m *MockRepositoryInterface) PurgeUser(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockRepositoryInterfaceMockRecorder) PurgeUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockRepositoryInterface)(nil).PurgeUser), arg0, arg1)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockRepositoryInterface) ReactivateUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockRepositoryInterfaceMockRecorder) ReactivateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockRepositoryInterface)(nil).ReactivateUser), arg0, arg1)
+}
+
+// RenameUser mocks base method.
+func (m *MockRepositoryInterface) RenameUser(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameUser", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameUser indicates an expected call of RenameUser.
+func (mr *MockRepositoryInterfaceMockRecorder) RenameUser(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameUser", reflect.TypeOf((*MockRepositoryInterface)(nil).RenameUser), arg0, arg1, arg2, arg3, arg4)
+}
+
+// RepairFollows mocks base method.
+func (m *MockRepositoryInterface) RepairFollows(arg0 context.Context, arg1 bool) (*models.FollowRepair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairFollows", arg0, arg1)
+	ret0, _ := ret[0].(*models.FollowRepair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairFollows indicates an expected call of RepairFollows.
+func (mr *MockRepositoryInterfaceMockRecorder) RepairFollows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairFollows", reflect.TypeOf((*MockRepositoryInterface)(nil).RepairFollows), arg0, arg1)
+}
+
+// SaveSuggestions mocks base method.
+func (m *MockRepositoryInterface) SaveSuggestions(arg0 context.Context, arg1 string, arg2 *[]models.Suggestion, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSuggestions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSuggestions indicates an expected call of SaveSuggestions.
+func (mr *MockRepositoryInterfaceMockRecorder) SaveSuggestions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSuggestions", reflect.TypeOf((*MockRepositoryInterface)(nil).SaveSuggestions), arg0, arg1, arg2, arg3)
+}
+
+// SetPassword mocks base method.
+func (m *MockRepositoryInterface) SetPassword(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockRepositoryInterfaceMockRecorder) SetPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockRepositoryInterface)(nil).SetPassword), arg0, arg1, arg2)
+}
+
+// SignIn mocks base method.
+func (m *MockRepositoryInterface) SignIn(arg0 context.Context, arg1 *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockRepositoryInterfaceMockRecorder) SignIn(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockRepositoryInterface)(nil).SignIn), arg0, arg1)
+}
+
+// SubscribeList mocks base method.
+func (m *MockRepositoryInterface) SubscribeList(arg0 context.Context, arg1 *models.ListSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeList indicates an expected call of SubscribeList.
+func (mr *MockRepositoryInterfaceMockRecorder) SubscribeList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeList", reflect.TypeOf((*MockRepositoryInterface)(nil).SubscribeList), arg0, arg1)
+}
+
+// UnpinTweet mocks base method.
+func (m *MockRepositoryInterface) UnpinTweet(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinTweet", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpinTweet indicates an expected call of UnpinTweet.
+func (mr *MockRepositoryInterfaceMockRecorder) UnpinTweet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinTweet", reflect.TypeOf((*MockRepositoryInterface)(nil).UnpinTweet), arg0, arg1)
+}
+
+// UnsubscribeList mocks base method.
+func (m *MockRepositoryInterface) UnsubscribeList(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsubscribeList indicates an expected call of UnsubscribeList.
+func (mr *MockRepositoryInterfaceMockRecorder) UnsubscribeList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeList", reflect.TypeOf((*MockRepositoryInterface)(nil).UnsubscribeList), arg0, arg1, arg2)
+}
+
+// UpdateDraft mocks base method.
+func (m *MockRepositoryInterface) UpdateDraft(arg0 context.Context, arg1 *models.Draft) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDraft", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDraft indicates an expected call of UpdateDraft.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateDraft(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDraft", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateDraft), arg0, arg1)
+}
+
+// WithinTransaction mocks base method.
+func (m *MockRepositoryInterface) WithinTransaction(arg0 context.Context, arg1 func(RepositoryInterface) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockRepositoryInterfaceMockRecorder) WithinTransaction(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockRepositoryInterface)(nil).SignIn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockRepositoryInterface)(nil).WithinTransaction), arg0, arg1)
 }
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (repository *MySQLRepository) DeactivateUser(ctx context.Context, username string, now time.Time) error {
+	db := repository.db.WithContext(ctx)
+	rows := db.Model(&models.User{}).
+		Where("BINARY name = ? and deactivated_at IS NULL", username).
+		Update("deactivated_at", now).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("active user %q: %w", username, ErrNotFound)
+	}
+	return nil
+}
+
+func (repository *MySQLRepository) ReactivateUser(ctx context.Context, username string) error {
+	db := repository.db.WithContext(ctx)
+	err := db.Model(&models.User{}).
+		Where("BINARY name = ? and deactivated_at IS NOT NULL", username).
+		Update("deactivated_at", nil).Error
+	return err
+}
+
+// GetUsersToPurge returns a page of the accounts deactivated before the
+// cutoff, oldest first. The next page starts after the last account of the
+// previous one, so accounts that could not be purged are not read again.
+func (repository *MySQLRepository) GetUsersToPurge(ctx context.Context, deactivatedBefore time.Time, after *models.User, limit int) ([]models.User, error) {
+	db := repository.db.WithContext(ctx)
+	query := db.Select("id", "name", "deactivated_at").Where("deactivated_at < ?", deactivatedBefore)
+	if after != nil {
+		query = query.Where("(deactivated_at, id) > (?, ?)", after.DeactivatedAt, after.ID)
+	}
+	var users []models.User
+	err := query.Order("deactivated_at, id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+// PurgeUser removes a user and everything that belongs to them for good, in
+// one transaction. It returns the blob keys of the removed media so the
+// caller can delete the files once the rows are gone.
+func (repository *MySQLRepository) PurgeUser(ctx context.Context, username string) ([]string, error) {
+	db := repository.db.WithContext(ctx)
+	var blobKeys []string
+	err := db.Transaction(func(tx *gorm.DB) error {
+		//purged rows are deleted for real, not soft deleted
+		tx = tx.Unscoped().Session(&gorm.Session{})
+		var user models.User
+		rows := tx.Where("BINARY name = ? and deactivated_at IS NOT NULL", username).Find(&user).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("deactivated user %q: %w", username, ErrNotFound)
+		}
+		tweets := tx.Model(&models.Tweet{}).Select("id").Where("user_id = ?", user.ID)
+		polls := tx.Model(&models.Poll{}).Select("id").Where("tweet_id IN (?)", tweets)
+		lists := tx.Model(&models.List{}).Select("id").Where("BINARY owner_name = ?", username)
+
+		var media []models.Media
+		err := tx.Where("BINARY user_name = ? or tweet_id IN (?)", username, tweets).Find(&media).Error
+		if err != nil {
+			return err
+		}
+		for _, m := range media {
+			blobKeys = append(blobKeys, m.BlobKey, m.ThumbnailKey)
+		}
+		var exports []models.DataExport
+		err = tx.Where("BINARY user_name = ? and blob_key <> ''", username).Find(&exports).Error
+		if err != nil {
+			return err
+		}
+		for _, export := range exports {
+			blobKeys = append(blobKeys, export.BlobKey)
+		}
+
+		//children first, the tables have foreign keys on tweets and polls
+		deletes := []struct {
+			model interface{}
+			query string
+			args  []interface{}
+		}{
+			{&models.PollVote{}, "poll_id IN (?) or BINARY user_name = ?", []interface{}{polls, username}},
+			{&models.PollOption{}, "poll_id IN (?)", []interface{}{polls}},
+			{&models.Poll{}, "tweet_id IN (?)", []interface{}{tweets}},
+			{&models.Media{}, "BINARY user_name = ? or tweet_id IN (?)", []interface{}{username, tweets}},
+			{&models.Bookmark{}, "BINARY user_name = ? or tweet_id IN (?)", []interface{}{username, tweets}},
+			{&models.BookmarkFolder{}, "BINARY user_name = ?", []interface{}{username}},
+			{&models.ListMember{}, "BINARY user_name = ? or list_id IN (?)", []interface{}{username, lists}},
+			{&models.ListSubscription{}, "BINARY user_name = ? or list_id IN (?)", []interface{}{username, lists}},
+			{&models.List{}, "BINARY owner_name = ?", []interface{}{username}},
+			{&models.Draft{}, "BINARY user_name = ?", []interface{}{username}},
+			{&models.Suggestion{}, "BINARY user_name = ? or BINARY candidate = ?", []interface{}{username, username}},
+			{&models.SuggestionState{}, "BINARY user_name = ?", []interface{}{username}},
+			{&models.DataExport{}, "BINARY user_name = ?", []interface{}{username}},
+			{&models.UsernameHistory{}, "user_id = ?", []interface{}{user.ID}},
+			{&models.Follows{}, "source_user_id = ? or target_user_id = ?", []interface{}{user.ID, user.ID}},
+			{&models.Tweet{}, "user_id = ?", []interface{}{user.ID}},
+		}
+		for _, d := range deletes {
+			err = tx.Where(d.query, d.args...).Delete(d.model).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&user).Error
+	})
+	return blobKeys, err
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUsersToPurgeAfter(t *testing.T) {
+	repository, mock := newMockRepository(t)
+	cutoff := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	deactivatedAt := cutoff.Add(-time.Hour)
+	mock.ExpectQuery("SELECT `id`,`name`,`deactivated_at` FROM `users` WHERE deactivated_at < \\? AND \\(deactivated_at, id\\) > \\(\\?, \\?\\) AND `users`.`deleted_at` IS NULL ORDER BY deactivated_at, id LIMIT 100").
+		WithArgs(cutoff, deactivatedAt, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "deactivated_at"}).AddRow(8, "abc", deactivatedAt))
+
+	after := models.User{Name: "def", DeactivatedAt: &deactivatedAt}
+	after.ID = 7
+	users, err := repository.GetUsersToPurge(context.Background(), cutoff, &after, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "abc", users[0].Name)
+}
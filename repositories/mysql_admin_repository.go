@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+	"time"
+)
+
+func (repository *MySQLRepository) DisableUser(ctx context.Context, username string, now time.Time) error {
+	db := repository.db.WithContext(ctx)
+	rows := db.Model(&models.User{}).
+		Where("BINARY name = ? and disabled_at IS NULL", username).
+		Update("disabled_at", now).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("enabled user %q: %w", username, ErrNotFound)
+	}
+	return nil
+}
+
+func (repository *MySQLRepository) EnableUser(ctx context.Context, username string) error {
+	db := repository.db.WithContext(ctx)
+	rows := db.Model(&models.User{}).
+		Where("BINARY name = ? and disabled_at IS NOT NULL", username).
+		Update("disabled_at", nil).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("disabled user %q: %w", username, ErrNotFound)
+	}
+	return nil
+}
+
+func (repository *MySQLRepository) SetPassword(ctx context.Context, username string, password string) error {
+	db := repository.db.WithContext(ctx)
+	//check if user exists, an unchanged password updates no rows
+	var user models.User
+	rows := db.Where("BINARY name = ?", username).Find(&user).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("user %q: %w", username, ErrNotFound)
+	}
+	return db.Model(&user).Update("password", password).Error
+}
+
+// ListUsers returns every account for the admin tool, including those that
+// are pending deletion or disabled. Passwords are not read.
+func (repository *MySQLRepository) ListUsers(ctx context.Context) (*[]models.User, error) {
+	db := repository.db.WithContext(ctx)
+	var users []models.User
+	err := db.Select("id", "name", "created_at", "deactivated_at", "disabled_at").Order("id").Find(&users).Error
+	return &users, err
+}
+
+func (repository *MySQLRepository) GetStats(ctx context.Context) (*models.Stats, error) {
+	db := repository.db.WithContext(ctx)
+	var stats models.Stats
+	counts := []struct {
+		model interface{}
+		query string
+		count *int64
+	}{
+		{&models.User{}, "", &stats.Users},
+		{&models.User{}, "deactivated_at IS NOT NULL", &stats.DeactivatedUsers},
+		{&models.User{}, "disabled_at IS NOT NULL", &stats.DisabledUsers},
+		{&models.Tweet{}, "", &stats.Tweets},
+		{&models.Follows{}, "", &stats.Follows},
+		{&models.Media{}, "", &stats.Media},
+	}
+	for _, c := range counts {
+		query := db.Model(c.model)
+		if c.query != "" {
+			query = query.Where(c.query)
+		}
+		err := query.Count(c.count).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &stats, nil
+}
+
+// RepairFollows removes users following themselves. The rows are deleted
+// outright like unfollowed edges, and duplicates need no repair since the
+// unique index on follows rules them out.
+func (repository *MySQLRepository) RepairFollows(ctx context.Context, dryRun bool) (*models.FollowRepair, error) {
+	db := repository.db.WithContext(ctx).Unscoped()
+	repair := models.FollowRepair{DryRun: dryRun}
+	const selfFollows = "source_user_id = target_user_id"
+	if dryRun {
+		err := db.Model(&models.Follows{}).Where(selfFollows).Count(&repair.SelfFollows).Error
+		if err != nil {
+			return nil, err
+		}
+		return &repair, nil
+	}
+	result := db.Where(selfFollows).Delete(&models.Follows{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	repair.SelfFollows = result.RowsAffected
+	return &repair, nil
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepairFollows(t *testing.T) {
+	type testCase struct {
+		name     string
+		dryRun   bool
+		expected *models.FollowRepair
+	}
+	testCases := []testCase{{name: "repair",
+		dryRun:   false,
+		expected: &models.FollowRepair{SelfFollows: 2}},
+		{name: "dry run",
+			dryRun:   true,
+			expected: &models.FollowRepair{SelfFollows: 2, DryRun: true}}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			repository, mock := newMockRepository(t)
+			if test.dryRun {
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `follows` WHERE source_user_id = target_user_id$").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			} else {
+				//a soft-deleted self-follow would still hold its place in the unique index
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `follows` WHERE source_user_id = target_user_id$").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			}
+
+			repair, err := repository.RepairFollows(context.Background(), test.dryRun)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, repair)
+		})
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	repository, mock := newMockRepository(t)
+	deactivatedAt := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	//accounts pending deletion are listed too, passwords are not read
+	mock.ExpectQuery("SELECT `id`,`name`,`created_at`,`deactivated_at`,`disabled_at` FROM `users` WHERE `users`.`deleted_at` IS NULL ORDER BY id$").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "deactivated_at"}).AddRow(1, "abc", nil).AddRow(2, "def", deactivatedAt))
+
+	users, err := repository.ListUsers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, *users, 2)
+	assert.Equal(t, &deactivatedAt, (*users)[1].DeactivatedAt)
+}
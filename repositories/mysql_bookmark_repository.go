@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func (repository *MySQLRepository) AddBookmark(ctx context.Context, bookmark *models.Bookmark) error {
+	db := repository.db.WithContext(ctx)
+	// check if tweet exists
+	var tweet models.Tweet
+	rows := db.Where("id = ?", bookmark.TweetID).Find(&tweet).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("tweet %d: %w", bookmark.TweetID, ErrNotFound)
+	}
+	//bookmarks can only go into the user's own folders
+	if bookmark.FolderID != nil {
+		var folder models.BookmarkFolder
+		rows = db.Where("id = ? and BINARY user_name = ?", *bookmark.FolderID, bookmark.UserName).Find(&folder).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("bookmark folder %d: %w", *bookmark.FolderID, ErrNotFound)
+		}
+	}
+	//a tweet sent along would be saved as a new tweet and the bookmark moved
+	//onto it, past the checks above and those of AddTweet
+	bookmark.Tweet = nil
+	err := db.Create(bookmark).Error
+	return conflict(err, fmt.Sprintf("bookmark of tweet %d", bookmark.TweetID))
+}
+
+func (repository *MySQLRepository) DeleteBookmark(ctx context.Context, username string, tweetid int) error {
+	db := repository.db.WithContext(ctx)
+	//bookmarks are removed for good so the tweet can be bookmarked again
+	var bookmark models.Bookmark
+	err := db.Unscoped().Delete(&bookmark, "BINARY user_name = ? and tweet_id = ?", username, tweetid).Error
+	return err
+}
+
+func (repository *MySQLRepository) GetBookmarks(ctx context.Context, username string, folderid *uint, after uint, limit int) (*[]models.Bookmark, error) {
+	db := repository.db.WithContext(ctx)
+	var bookmarks []models.Bookmark
+	//newest first, skipping bookmarks of deleted tweets
+	query := db.Preload("Tweet", tweetsWithAuthor).Preload("Tweet.Media").
+		Joins("JOIN tweets ON tweets.id = bookmarks.tweet_id and tweets.deleted_at IS NULL").
+		Where("BINARY bookmarks.user_name = ?", username)
+	if folderid != nil {
+		query = query.Where("bookmarks.folder_id = ?", *folderid)
+	}
+	if after != 0 {
+		query = query.Where("bookmarks.id < ?", after)
+	}
+	err := query.Order("bookmarks.id DESC").Limit(limit).Find(&bookmarks).Error
+	return &bookmarks, err
+}
+
+func (repository *MySQLRepository) AddBookmarkFolder(ctx context.Context, folder *models.BookmarkFolder) error {
+	db := repository.db.WithContext(ctx)
+	// check if user exists
+	var user models.User
+	rows := db.Where("BINARY name = ?", folder.UserName).Find(&user).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("user %q: %w", folder.UserName, ErrNotFound)
+	}
+	return db.Create(folder).Error
+}
+
+func (repository *MySQLRepository) GetBookmarkFolders(ctx context.Context, username string) (*[]models.BookmarkFolder, error) {
+	db := repository.db.WithContext(ctx)
+	var folders []models.BookmarkFolder
+	err := db.Where("BINARY user_name = ?", username).Find(&folders).Error
+	return &folders, err
+}
+
+func (repository *MySQLRepository) DeleteBookmarkFolder(ctx context.Context, username string, folderid int) error {
+	db := repository.db.WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		rows := tx.Where("BINARY user_name = ?", username).Delete(&models.BookmarkFolder{}, folderid).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("bookmark folder %d: %w", folderid, ErrNotFound)
+		}
+		//keep the bookmarks, they just no longer belong to a folder
+		return tx.Model(&models.Bookmark{}).Where("folder_id = ?", folderid).Update("folder_id", nil).Error
+	})
+}
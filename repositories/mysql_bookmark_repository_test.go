@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAddBookmarkIgnoresSentTweet(t *testing.T) {
+	repository, mock := newMockRepository(t)
+	mock.ExpectQuery("SELECT \\* FROM `tweets`").WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_name"}).AddRow(5, "def"))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `bookmarks`").WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+
+	//the tweet would otherwise be inserted with media 7 and the bookmark moved onto it
+	tweet := models.Tweet{Content: "forged", Media: []models.Media{{Model: gorm.Model{ID: 7}}}}
+	bookmark := models.Bookmark{UserName: "abc", TweetID: 5, Tweet: &tweet}
+	err := repository.AddBookmark(context.Background(), &bookmark)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), bookmark.TweetID)
+	assert.Nil(t, bookmark.Tweet)
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+	"time"
+)
+
+func (repository *MySQLRepository) AddDataExport(ctx context.Context, export *models.DataExport) error {
+	db := repository.db.WithContext(ctx)
+	// check if user exists
+	var user models.User
+	rows := db.Where("BINARY name = ?", export.UserName).Find(&user).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("user %q: %w", export.UserName, ErrNotFound)
+	}
+	return db.Create(export).Error
+}
+
+func (repository *MySQLRepository) GetLatestDataExport(ctx context.Context, username string) (*models.DataExport, error) {
+	db := repository.db.WithContext(ctx)
+	var export models.DataExport
+	err := db.Where("BINARY user_name = ?", username).Order("id DESC").First(&export).Error
+	return &export, err
+}
+
+func (repository *MySQLRepository) GetDataExportByToken(ctx context.Context, token string) (*models.DataExport, error) {
+	db := repository.db.WithContext(ctx)
+	var export models.DataExport
+	err := db.Where("BINARY token = ? and status = ?", token, models.DataExportReady).First(&export).Error
+	return &export, err
+}
+
+func (repository *MySQLRepository) ClaimPendingDataExports(ctx context.Context, owner string, now time.Time, lease time.Duration, limit int) (*[]models.DataExport, error) {
+	db := repository.db.WithContext(ctx)
+	//the conditional update is atomic, so concurrent workers never claim the same export
+	until := now.Add(lease)
+	err := db.Model(&models.DataExport{}).
+		Where("status = ?", models.DataExportPending).
+		Where("claimed_until IS NULL or claimed_until < ?", now).
+		Order("id").
+		Limit(limit).
+		Updates(map[string]interface{}{"claimed_by": owner, "claimed_until": until}).Error
+	if err != nil {
+		return nil, err
+	}
+	var exports []models.DataExport
+	err = db.Where("claimed_by = ? and status = ?", owner, models.DataExportPending).Find(&exports).Error
+	return &exports, err
+}
+
+func (repository *MySQLRepository) MarkDataExportReady(ctx context.Context, exportid uint, blobKey string, token string, expiresAt time.Time) error {
+	db := repository.db.WithContext(ctx)
+	return db.Model(&models.DataExport{}).Where("id = ?", exportid).
+		Updates(map[string]interface{}{
+			"status":     models.DataExportReady,
+			"blob_key":   blobKey,
+			"token":      token,
+			"expires_at": expiresAt,
+		}).Error
+}
+
+func (repository *MySQLRepository) MarkDataExportFailed(ctx context.Context, exportid uint, reason string, expiresAt time.Time) error {
+	db := repository.db.WithContext(ctx)
+	return db.Model(&models.DataExport{}).Where("id = ?", exportid).
+		Updates(map[string]interface{}{
+			"status":     models.DataExportFailed,
+			"error":      reason,
+			"expires_at": expiresAt,
+		}).Error
+}
+
+// GetExpiredDataExports returns ready and failed exports past their expiry.
+func (repository *MySQLRepository) GetExpiredDataExports(ctx context.Context, now time.Time, limit int) (*[]models.DataExport, error) {
+	db := repository.db.WithContext(ctx)
+	var exports []models.DataExport
+	err := db.Where("expires_at < ?", now).Limit(limit).Find(&exports).Error
+	return &exports, err
+}
+
+func (repository *MySQLRepository) DeleteDataExport(ctx context.Context, exportid uint) error {
+	db := repository.db.WithContext(ctx)
+	return db.Unscoped().Delete(&models.DataExport{}, exportid).Error
+}
+
+func (repository *MySQLRepository) GetUser(ctx context.Context, username string) (*models.User, error) {
+	db := repository.db.WithContext(ctx)
+	var user models.User
+	err := db.Where("BINARY name = ?", username).First(&user).Error
+	return &user, err
+}
+
+func (repository *MySQLRepository) GetUsernameHistory(ctx context.Context, username string) (*[]models.UsernameHistory, error) {
+	db := repository.db.WithContext(ctx)
+	var history []models.UsernameHistory
+	users := db.Model(&models.User{}).Select("id").Where("BINARY name = ?", username)
+	err := db.Where("user_id = (?)", users).Order("id").Find(&history).Error
+	return &history, err
+}
+
+func (repository *MySQLRepository) GetFollowersOfUser(ctx context.Context, username string) (*[]models.Follows, error) {
+	db := repository.db.WithContext(ctx)
+	var followers []models.Follows
+	err := db.Scopes(followsWithNames).Where("BINARY target.name = ?", username).Find(&followers).Error
+	return &followers, err
+}
+
+func (repository *MySQLRepository) GetMediaOfUser(ctx context.Context, username string) (*[]models.Media, error) {
+	db := repository.db.WithContext(ctx)
+	var media []models.Media
+	err := db.Where("BINARY user_name = ?", username).Find(&media).Error
+	return &media, err
+}
+
+func (repository *MySQLRepository) GetPollVotesOfUser(ctx context.Context, username string) (*[]models.PollVote, error) {
+	db := repository.db.WithContext(ctx)
+	var votes []models.PollVote
+	err := db.Where("BINARY user_name = ?", username).Order("id").Find(&votes).Error
+	return &votes, err
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+	"time"
+)
+
+func (repository *MySQLRepository) AddDraft(ctx context.Context, draft *models.Draft) error {
+	db := repository.db.WithContext(ctx)
+	// check if user exists
+	var user models.User
+	rows := db.Where("BINARY name = ?", draft.UserName).Find(&user).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("user %q: %w", draft.UserName, ErrNotFound)
+	}
+	return db.Create(draft).Error
+}
+
+func (repository *MySQLRepository) GetDraftsOfUser(ctx context.Context, username string) (*[]models.Draft, error) {
+	db := repository.db.WithContext(ctx)
+	var drafts []models.Draft
+	err := db.Where("BINARY user_name = ? and published_at IS NULL", username).Find(&drafts).Error
+	return &drafts, err
+}
+
+func (repository *MySQLRepository) GetDraft(ctx context.Context, draftid int) (*models.Draft, error) {
+	db := repository.db.WithContext(ctx)
+	var draft models.Draft
+	err := db.First(&draft, draftid).Error
+	return &draft, err
+}
+
+func (repository *MySQLRepository) UpdateDraft(ctx context.Context, draft *models.Draft) error {
+	db := repository.db.WithContext(ctx)
+	//published drafts are final, and an edit releases any scheduler claim
+	rows := db.Model(&models.Draft{}).
+		Where("id = ? and published_at IS NULL", draft.ID).
+		Updates(map[string]interface{}{
+			"content":       draft.Content,
+			"publish_at":    draft.PublishAt,
+			"error":         "",
+			"claimed_by":    "",
+			"claimed_until": nil,
+		}).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("unpublished draft %d: %w", draft.ID, ErrNotFound)
+	}
+	return nil
+}
+
+func (repository *MySQLRepository) DeleteDraft(ctx context.Context, draftid int) error {
+	db := repository.db.WithContext(ctx)
+	var draft models.Draft
+	err := db.Delete(&draft, draftid).Error
+	return err
+}
+
+func (repository *MySQLRepository) ClaimDueDrafts(ctx context.Context, owner string, now time.Time, lease time.Duration, limit int) (*[]models.Draft, error) {
+	db := repository.db.WithContext(ctx)
+	//the conditional update is atomic, so concurrent schedulers never claim the same draft
+	until := now.Add(lease)
+	err := db.Model(&models.Draft{}).
+		Where("publish_at <= ? and published_at IS NULL", now).
+		Where("claimed_until IS NULL or claimed_until < ?", now).
+		Order("publish_at").
+		Limit(limit).
+		Updates(map[string]interface{}{"claimed_by": owner, "claimed_until": until}).Error
+	if err != nil {
+		return nil, err
+	}
+	var drafts []models.Draft
+	err = db.Where("claimed_by = ? and published_at IS NULL", owner).Find(&drafts).Error
+	return &drafts, err
+}
+
+func (repository *MySQLRepository) MarkDraftPublished(ctx context.Context, draftid uint) error {
+	db := repository.db.WithContext(ctx)
+	//a draft only counts as published once its tweet exists
+	var tweet models.Tweet
+	rows := db.Where("draft_id = ?", draftid).Find(&tweet).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("tweet of draft %d: %w", draftid, ErrNotFound)
+	}
+	return db.Model(&models.Draft{}).Where("id = ?", draftid).
+		Updates(map[string]interface{}{"published_at": tweet.CreatedAt, "tweet_id": tweet.ID}).Error
+}
+
+func (repository *MySQLRepository) MarkDraftFailed(ctx context.Context, draftid uint, reason string) error {
+	db := repository.db.WithContext(ctx)
+	//unschedule the draft so the owner can fix and reschedule it
+	return db.Model(&models.Draft{}).Where("id = ?", draftid).
+		Updates(map[string]interface{}{
+			"publish_at":    nil,
+			"error":         reason,
+			"claimed_by":    "",
+			"claimed_until": nil,
+		}).Error
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxListsPerUser = 1000
+	maxListMembers  = 5000
+)
+
+func (repository *MySQLRepository) AddList(ctx context.Context, list *models.List) error {
+	db := repository.db.WithContext(ctx)
+	// check if user exists
+	var user models.User
+	rows := db.Where("BINARY name = ?", list.OwnerName).Find(&user).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("user %q: %w", list.OwnerName, ErrNotFound)
+	}
+	var count int64
+	db.Model(&models.List{}).Where("BINARY owner_name = ?", list.OwnerName).Count(&count)
+	if count >= maxListsPerUser {
+		return fmt.Errorf("%w: at most %d lists per user", ErrInvalid, maxListsPerUser)
+	}
+	return db.Create(list).Error
+}
+
+func (repository *MySQLRepository) GetList(ctx context.Context, listid int) (*models.List, error) {
+	db := repository.db.WithContext(ctx)
+	var list models.List
+	err := db.First(&list, listid).Error
+	return &list, err
+}
+
+func (repository *MySQLRepository) GetListsOfUser(ctx context.Context, username string) (*[]models.List, error) {
+	db := repository.db.WithContext(ctx)
+	//lists the user owns and lists the user subscribed to
+	var lists []models.List
+	subscribed := db.Model(&models.ListSubscription{}).Select("list_id").Where("BINARY user_name = ?", username)
+	err := db.Where("BINARY owner_name = ? or id IN (?)", username, subscribed).Find(&lists).Error
+	return &lists, err
+}
+
+func (repository *MySQLRepository) DeleteList(ctx context.Context, listid int) error {
+	db := repository.db.WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&models.List{}, listid).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Unscoped().Where("list_id = ?", listid).Delete(&models.ListMember{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("list_id = ?", listid).Delete(&models.ListSubscription{}).Error
+	})
+}
+
+func (repository *MySQLRepository) AddListMember(ctx context.Context, member *models.ListMember) error {
+	db := repository.db.WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		// check if user exists
+		var user models.User
+		rows := tx.Where("BINARY name = ?", member.UserName).Find(&user).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("user %q: %w", member.UserName, ErrNotFound)
+		}
+		//lock the list so concurrent additions cannot pass the limit together
+		var list models.List
+		rows = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", member.ListID).Find(&list).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("list %d: %w", member.ListID, ErrNotFound)
+		}
+		var count int64
+		err := tx.Model(&models.ListMember{}).Where("list_id = ?", member.ListID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count >= maxListMembers {
+			return fmt.Errorf("%w: at most %d members per list", ErrInvalid, maxListMembers)
+		}
+		err = tx.Create(member).Error
+		return conflict(err, fmt.Sprintf("member %q", member.UserName))
+	})
+}
+
+func (repository *MySQLRepository) DeleteListMember(ctx context.Context, listid int, username string) error {
+	db := repository.db.WithContext(ctx)
+	var member models.ListMember
+	err := db.Unscoped().Delete(&member, "list_id = ? and BINARY user_name = ?", listid, username).Error
+	return err
+}
+
+func (repository *MySQLRepository) GetListMembers(ctx context.Context, listid int) (*[]models.ListMember, error) {
+	db := repository.db.WithContext(ctx)
+	var members []models.ListMember
+	err := db.Where("list_id = ?", listid).Find(&members).Error
+	return &members, err
+}
+
+func (repository *MySQLRepository) SubscribeList(ctx context.Context, subscription *models.ListSubscription) error {
+	db := repository.db.WithContext(ctx)
+	err := db.Create(subscription).Error
+	return conflict(err, fmt.Sprintf("subscription of %q", subscription.UserName))
+}
+
+func (repository *MySQLRepository) UnsubscribeList(ctx context.Context, listid int, username string) error {
+	db := repository.db.WithContext(ctx)
+	var subscription models.ListSubscription
+	err := db.Unscoped().Delete(&subscription, "list_id = ? and BINARY user_name = ?", listid, username).Error
+	return err
+}
+
+func (repository *MySQLRepository) GetListTimeline(ctx context.Context, listid int, limit int) (*[]models.Tweet, error) {
+	db := repository.db.WithContext(ctx)
+	//tweets of the list members, joined the same way follows relate users
+	var tweets []models.Tweet
+	err := db.Scopes(tweetsWithAuthor).Preload("Media").
+		Joins("JOIN list_members ON BINARY list_members.user_name = users.name and list_members.list_id = ? and list_members.deleted_at IS NULL", listid).
+		Order("tweets.id DESC").
+		Limit(limit).
+		Find(&tweets).Error
+	return &tweets, err
+}
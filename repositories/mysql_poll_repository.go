@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (repository *MySQLRepository) GetPoll(ctx context.Context, tweetid int) (*models.Poll, error) {
+	db := repository.db.WithContext(ctx)
+	var poll models.Poll
+	err := db.Preload("Options").Where("tweet_id = ?", tweetid).First(&poll).Error
+	return &poll, err
+}
+
+func (repository *MySQLRepository) AddPollVote(ctx context.Context, vote *models.PollVote, now time.Time) error {
+	db := repository.db.WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		// check if user exists
+		var user models.User
+		rows := tx.Where("BINARY name = ?", vote.UserName).Find(&user).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("user %q: %w", vote.UserName, ErrNotFound)
+		}
+		//lock the poll so it cannot close between the check and the insert
+		var poll models.Poll
+		rows = tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("id = ? and closes_at > ?", vote.PollID, now).Find(&poll).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("open poll %d: %w", vote.PollID, ErrNotFound)
+		}
+		var option models.PollOption
+		rows = tx.Where("id = ? and poll_id = ?", vote.OptionID, vote.PollID).Find(&option).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("%w: option %d is not part of the poll", ErrInvalid, vote.OptionID)
+		}
+		//the unique index on (poll_id, user_name) rejects a second vote
+		err := tx.Create(vote).Error
+		return conflict(err, fmt.Sprintf("vote of %q", vote.UserName))
+	})
+}
+
+func (repository *MySQLRepository) GetPollVote(ctx context.Context, pollid uint, username string) (*models.PollVote, error) {
+	db := repository.db.WithContext(ctx)
+	//a missing vote is not an error, the user just has not voted yet
+	var vote models.PollVote
+	result := db.Where("poll_id = ? and BINARY user_name = ?", pollid, username).Find(&vote)
+	if result.Error != nil || result.RowsAffected != 1 {
+		return nil, result.Error
+	}
+	return &vote, nil
+}
+
+func (repository *MySQLRepository) CountPollVotes(ctx context.Context, pollid uint) (map[uint]int, error) {
+	db := repository.db.WithContext(ctx)
+	var counts []struct {
+		OptionID uint
+		Votes    int
+	}
+	err := db.Model(&models.PollVote{}).
+		Select("option_id, count(*) as votes").
+		Where("poll_id = ?", pollid).
+		Group("option_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint]int, len(counts))
+	for _, count := range counts {
+		result[count.OptionID] = count.Votes
+	}
+	return result, nil
+}
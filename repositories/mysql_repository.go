@@ -1,143 +1,333 @@
 package repositories
 
 import (
-	"errors"
+	"context"
 	"example/layered-architecture/models"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/gorm/clause"
 )
 
 type MySQLRepository struct {
 	db *gorm.DB
 }
 
-func NewMySqlRepository(dsn string) *MySQLRepository {
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Info)})
+// Options tune the connection pool and logging of the database.
+type Options struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// ConnectRetry is how long a failing connection is retried, so that the
+	// server can start before the database is up. Zero gives up at once.
+	ConnectRetry time.Duration
+}
 
-	if err != nil {
-		panic("cannot connect to DB!!")
-	}
-	err = db.AutoMigrate(&models.User{})
-	if err != nil {
-		panic("cannot initiate user table")
+const (
+	minConnectBackoff = 500 * time.Millisecond
+	maxConnectBackoff = 10 * time.Second
+)
+
+// NewMySqlRepository connects to the database, retrying with growing pauses
+// for options.ConnectRetry or until ctx is cancelled. It does not touch the
+// schema, see MigrateUp.
+func NewMySqlRepository(ctx context.Context, dsn string, options Options) (*MySQLRepository, error) {
+	giveUp := time.Now().Add(options.ConnectRetry)
+	backoff := minConnectBackoff
+	for {
+		//opening pings the database
+		db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: gormLogger{}})
+		if err == nil {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return nil, err
+			}
+			err = instrument(db)
+			if err != nil {
+				return nil, err
+			}
+			sqlDB.SetMaxOpenConns(options.MaxOpenConns)
+			sqlDB.SetMaxIdleConns(options.MaxIdleConns)
+			sqlDB.SetConnMaxLifetime(options.ConnMaxLifetime)
+			return &MySQLRepository{db: db}, nil
+		}
+		remaining := time.Until(giveUp)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("cannot connect to database: %w", err)
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+		slog.WarnContext(ctx, "cannot connect to database, retrying", "backoff", backoff.Round(time.Millisecond).String(), "error", err)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("cannot connect to database: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
 	}
-	err = db.AutoMigrate(&models.Follows{})
+}
+
+// Ping checks that the database can be reached.
+func (repository *MySQLRepository) Ping(ctx context.Context) error {
+	sqlDB, err := repository.db.DB()
 	if err != nil {
-		panic("cannot initiate followers table")
+		return err
 	}
-	err = db.AutoMigrate(&models.Tweet{})
+	return sqlDB.PingContext(ctx)
+}
+
+// Close closes the connection pool. Queries still running are waited for.
+func (repository *MySQLRepository) Close() error {
+	sqlDB, err := repository.db.DB()
 	if err != nil {
-		panic("cannot initiate tweets table")
+		return err
 	}
-	fmt.Println("connected to DB")
-	return &MySQLRepository{db: db}
-
+	return sqlDB.Close()
 }
 
-func (repository *MySQLRepository) AddUser(user *models.User) error {
-	//create record in table
-	err := repository.db.Create(user).Error
-	return err
+func (repository *MySQLRepository) WithinTransaction(ctx context.Context, fn func(repository RepositoryInterface) error) error {
+	//inside a transaction this nests as a savepoint
+	return repository.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&MySQLRepository{db: tx})
+	})
 }
 
-func (repository *MySQLRepository) SignIn(user *models.User) error {
+func (repository *MySQLRepository) AddUser(ctx context.Context, user *models.User) error {
+	db := repository.db.WithContext(ctx)
+	//handles given up recently stay reserved for their previous owner
+	var reserved models.UsernameHistory
+	rows := db.Where("BINARY old_name = ? and released_at > ?", user.Name, time.Now()).Find(&reserved).RowsAffected
+	if rows != 0 {
+		return fmt.Errorf("name %q: %w", user.Name, ErrConflict)
+	}
+	var taken models.User
+	rows = db.Unscoped().Where("BINARY name = ?", user.Name).Find(&taken).RowsAffected
+	if rows != 0 {
+		return fmt.Errorf("name %q: %w", user.Name, ErrConflict)
+	}
+	//create record in table, the unique name still guards against a
+	//concurrent sign up with the same name
+	err := db.Create(user).Error
+	return conflict(err, fmt.Sprintf("name %q", user.Name))
+}
 
+func (repository *MySQLRepository) SignIn(ctx context.Context, user *models.User) error {
+	db := repository.db.WithContext(ctx)
 	var signedinuser models.User
-	rows := repository.db.Where("BINARY name = ? and password = ?", user.Name, user.Password).Find(&signedinuser).RowsAffected
+	rows := db.Where("BINARY name = ? and password = ? and disabled_at IS NULL", user.Name, user.Password).Find(&signedinuser).RowsAffected
 	if rows != 1 {
-		return errors.New("bad request")
+		return ErrUnauthorized
 	}
 	return nil
 }
 
-func (repository *MySQLRepository) GetAllUsers() (*[]models.User, error) {
-
+func (repository *MySQLRepository) GetAllUsers(ctx context.Context) (*[]models.User, error) {
+	db := repository.db.WithContext(ctx)
 	var users []models.User
-	//select all records from users
-	err := repository.db.Find(&users).Error
+	//select all records from users, except accounts pending deletion
+	err := db.Where("deactivated_at IS NULL").Find(&users).Error
 	return &users, err
 }
 
-func (repository *MySQLRepository) AddTweet(tweet *models.Tweet) error {
+func (repository *MySQLRepository) AddTweet(ctx context.Context, tweet *models.Tweet) error {
+	db := repository.db.WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		//check if user exists
+		var user models.User
+		rows := tx.Where("BINARY name = ? and deactivated_at IS NULL and disabled_at IS NULL", tweet.UserName).Find(&user).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("active user %q: %w", tweet.UserName, ErrNotFound)
+		}
+		//media are only ever attached through MediaIDs, rows sent along with
+		//the tweet would be saved as they are and take over other uploads
+		tweet.Media = nil
+		//attached media must be unused uploads of the posting user, locked so
+		//that two tweets cannot take the same upload
+		if len(tweet.MediaIDs) > 0 {
+			var media []models.Media
+			rows = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id IN ? and BINARY user_name = ? and tweet_id IS NULL", tweet.MediaIDs, tweet.UserName).Find(&media).RowsAffected
+			if int(rows) != len(tweet.MediaIDs) {
+				return fmt.Errorf("%w: media must be unused uploads of the author", ErrInvalid)
+			}
+			//saved together with the tweet, which sets their tweet_id
+			tweet.Media = media
+		}
+		tweet.UserID = user.ID
+		//craete the tweet, a draft can only be published once
+		err := tx.Create(tweet).Error
+		return conflict(err, "tweet of the draft")
+	})
+}
 
-	//check if user exists
+func (repository *MySQLRepository) GetTweetsOfUser(ctx context.Context, username string) (*[]models.Tweet, error) {
+	db := repository.db.WithContext(ctx)
+	var tweets []models.Tweet
 	var user models.User
-	rows := repository.db.Where("BINARY name = ?", tweet.UserName).Find(&user).RowsAffected
-	if rows != 1 {
-		return errors.New("bad request")
+	err := db.Where("BINARY name = ?", username).Find(&user).Error
+	if err != nil {
+		return nil, err
 	}
-	//tweet validation
-	if len(tweet.Content) < 1 {
-		return errors.New("bad request")
+	query := db.Scopes(tweetsWithAuthor).Preload("Media").Preload("Poll.Options").Where("tweets.user_id = ?", user.ID)
+	//the pinned tweet always comes first
+	if user.PinnedTweetID != nil {
+		query = query.Order(clause.Expr{SQL: "tweets.id = ? DESC", Vars: []interface{}{*user.PinnedTweetID}})
 	}
-	//craete the tweet and return json
-	repository.db.Create(&tweet)
-	return nil
+	err = query.Find(&tweets).Error
+	for i := range tweets {
+		tweets[i].Pinned = user.PinnedTweetID != nil && tweets[i].ID == *user.PinnedTweetID
+	}
+	return &tweets, err
 }
 
-func (repository *MySQLRepository) GetTweetsOfUser(username string) (*[]models.Tweet, error) {
+func (repository *MySQLRepository) GetFolloweesOfUser(ctx context.Context, username string) (*[]models.Follows, error) {
+	db := repository.db.WithContext(ctx)
+	var followees []models.Follows
+	err := db.Scopes(followsWithNames).Where("BINARY source.name = ?", username).Find(&followees).Error
+	return &followees, err
+}
 
-	var tweets []models.Tweet
-	err := repository.db.Where("BINARY user_name = ?", username).Find(&tweets).Error
-	return &tweets, err
+func (repository *MySQLRepository) AddFollowee(ctx context.Context, follow *models.Follows) error {
+	db := repository.db.WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		// check if user exists
+		var user models.User
+		rows := tx.Where("BINARY name = ? and deactivated_at IS NULL and disabled_at IS NULL", follow.SourceUser).Find(&user).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("active user %q: %w", follow.SourceUser, ErrNotFound)
+		}
+
+		// check if the followed user exists too
+		var target models.User
+		rows = tx.Where("BINARY name = ? and deactivated_at IS NULL", follow.TargetUser).Find(&target).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("active user %q: %w", follow.TargetUser, ErrNotFound)
+		}
+		if target.ID == user.ID {
+			return fmt.Errorf("%w: users cannot follow themselves", ErrInvalid)
+		}
+		follow.SourceUserID = user.ID
+		follow.TargetUserID = target.ID
+
+		//the unique index on the pair rejects following twice
+		err := tx.Create(follow).Error
+		return conflict(err, fmt.Sprintf("follow of %q", follow.TargetUser))
+	})
 }
 
-func (repository *MySQLRepository) GetFolloweesOfUser(username string) (*[]models.Follows, error) {
+func (repository *MySQLRepository) DeleteTweet(ctx context.Context, tweetid int) error {
+	db := repository.db.WithContext(ctx)
+	//a deleted tweet cannot stay pinned or bookmarked, all of it happens or
+	//none of it
+	return db.Transaction(func(tx *gorm.DB) error {
+		var tweet models.Tweet
+		err := tx.Delete(&tweet, tweetid).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.User{}).Where("pinned_tweet_id = ?", tweetid).Update("pinned_tweet_id", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("tweet_id = ?", tweetid).Delete(&models.Bookmark{}).Error
+	})
+}
+func (repository *MySQLRepository) DeleteFollowee(ctx context.Context, username string, followeename string) error {
+	db := repository.db.WithContext(ctx)
+	var followee models.Follows
+	source := db.Model(&models.User{}).Select("id").Where("BINARY name = ?", username)
+	target := db.Model(&models.User{}).Select("id").Where("BINARY name = ?", followeename)
+	//unfollowing removes the edge for good, so following again can add it
+	err := db.Unscoped().Delete(&followee, "source_user_id = (?) and target_user_id = (?)", source, target).Error
+	return err
 
-	var followees []models.Follows
-	err := repository.db.Where("BINARY source_user = ?", username).Find(&followees).Error
-	return &followees, err
 }
 
-func (repository *MySQLRepository) AddFollowee(follow *models.Follows) error {
+func (repository *MySQLRepository) GetRelationship(ctx context.Context, source string, target string) (*models.Relationship, error) {
+	db := repository.db.WithContext(ctx)
+	//everything in a single round trip, including whether both users exist
+	var result struct {
+		SourceExists bool
+		TargetExists bool
+		Following    bool
+		FollowedBy   bool
+	}
+	err := db.Raw(`
+		SELECT
+			EXISTS(SELECT 1 FROM users WHERE BINARY name = ? and deleted_at IS NULL) AS source_exists,
+			EXISTS(SELECT 1 FROM users WHERE BINARY name = ? and deleted_at IS NULL) AS target_exists,
+			EXISTS(SELECT 1 FROM follows f JOIN users s ON s.id = f.source_user_id JOIN users t ON t.id = f.target_user_id
+				WHERE BINARY s.name = ? and BINARY t.name = ? and f.deleted_at IS NULL) AS following,
+			EXISTS(SELECT 1 FROM follows f JOIN users s ON s.id = f.source_user_id JOIN users t ON t.id = f.target_user_id
+				WHERE BINARY s.name = ? and BINARY t.name = ? and f.deleted_at IS NULL) AS followed_by`,
+		source, target, source, target, target, source).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	if !result.SourceExists || !result.TargetExists {
+		return nil, fmt.Errorf("users %q and %q: %w", source, target, ErrNotFound)
+	}
+	return &models.Relationship{
+		Source:     source,
+		Target:     target,
+		Following:  result.Following,
+		FollowedBy: result.FollowedBy,
+	}, nil
+}
 
+func (repository *MySQLRepository) AddMedia(ctx context.Context, media *models.Media) error {
+	db := repository.db.WithContext(ctx)
 	// check if user exists
 	var user models.User
-	rows := repository.db.Where("BINARY name = ?", follow.SourceUser).Find(&user).RowsAffected
+	rows := db.Where("BINARY name = ?", media.UserName).Find(&user).RowsAffected
 	if rows != 1 {
-		return errors.New("bad request")
+		return fmt.Errorf("user %q: %w", media.UserName, ErrNotFound)
 	}
+	return db.Create(media).Error
+}
 
-	var existing models.Follows
-
-	//check if the user is already following
-	rows = repository.db.Where("BINARY source_user = ? and target_user = ?", follow.SourceUser, follow.TargetUser).Find(&existing).RowsAffected
-	if rows == 1 {
-		return errors.New("bad request")
-	}
-	repository.db.Create(&follow)
-	return nil
+func (repository *MySQLRepository) GetMedia(ctx context.Context, mediaid int) (*models.Media, error) {
+	db := repository.db.WithContext(ctx)
+	var media models.Media
+	err := db.First(&media, mediaid).Error
+	return &media, err
 }
 
-func (repository *MySQLRepository) DeleteTweet(tweetid int) error {
+func (repository *MySQLRepository) PinTweet(ctx context.Context, username string, tweetid int) error {
+	db := repository.db.WithContext(ctx)
+	//only the author can pin a tweet
 	var tweet models.Tweet
-	err := repository.db.Delete(&tweet, tweetid).Error
-
+	author := db.Model(&models.User{}).Select("id").Where("BINARY name = ?", username)
+	rows := db.Where("id = ? and user_id = (?)", tweetid, author).Find(&tweet).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("tweet %d of %q: %w", tweetid, username, ErrNotFound)
+	}
+	err := db.Model(&models.User{}).Where("BINARY name = ?", username).Update("pinned_tweet_id", tweet.ID).Error
 	return err
 }
-func (repository *MySQLRepository) DeleteFollowee(username string, followeename string) error {
-	var followee models.Follows
-	err := repository.db.Delete(&followee, "BINARY source_user = ? and target_user = ?", username, followeename).Error
-	return err
 
+func (repository *MySQLRepository) UnpinTweet(ctx context.Context, username string) error {
+	db := repository.db.WithContext(ctx)
+	err := db.Model(&models.User{}).Where("BINARY name = ?", username).Update("pinned_tweet_id", nil).Error
+	return err
 }
 
-func (repository *MySQLRepository) CheckFollowing(username string, followeename string) error {
-	// check if user exists
-	var user models.User
-	var existing models.Follows
+// tweetsWithAuthor selects tweets along with the current handle of their
+// author, tweets only store the user id.
+func tweetsWithAuthor(db *gorm.DB) *gorm.DB {
+	return db.Select("tweets.*, users.name AS user_name").Joins("JOIN users ON users.id = tweets.user_id")
+}
 
-	rows := repository.db.Where("BINARY name = ?", username).Find(&user).RowsAffected
-	if rows != 1 {
-		return errors.New("bad request")
-	}
-	//check number of rows returned for the 2 users
-	rows = repository.db.Where("BINARY source_user = ? and target_user = ?", username, followeename).Find(&existing).RowsAffected
-	if rows == 1 {
-		return errors.New("bad request")
-	}
-	return nil
+// followsWithNames selects follows along with the handles of both users.
+func followsWithNames(db *gorm.DB) *gorm.DB {
+	return db.Select("follows.*, source.name AS source_user, target.name AS target_user").
+		Joins("JOIN users source ON source.id = follows.source_user_id").
+		Joins("JOIN users target ON target.id = follows.target_user_id")
 }
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// newMockRepository returns a repository on a mocked connection. Statements
+// that are not expected fail the test, which is how these tests check that
+// gorm does not write more than it should.
+func newMockRepository(t *testing.T) (*MySQLRepository, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		conn.Close()
+	})
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{Logger: gormLogger{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &MySQLRepository{db: db}, mock
+}
+
+func TestAddTweetIgnoresSentMedia(t *testing.T) {
+	repository, mock := newMockRepository(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `users`").WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "abc"))
+	mock.ExpectExec("INSERT INTO `tweets`").WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectCommit()
+
+	//media 7 is somebody else's upload, sent in media rather than mediaids
+	tweet := models.Tweet{UserName: "abc", Content: "mine now", Media: []models.Media{{Model: gorm.Model{ID: 7}}, {MimeType: "image/png"}}}
+	err := repository.AddTweet(context.Background(), &tweet)
+
+	assert.NoError(t, err)
+	assert.Empty(t, tweet.Media)
+}
+
+func TestDeleteTweet(t *testing.T) {
+	type testCase struct {
+		name        string
+		unpinErr    error
+		expectedErr error
+	}
+	testCases := []testCase{{name: "success",
+		unpinErr:    nil,
+		expectedErr: nil},
+		{name: "unpin fails",
+			unpinErr:    errors.New("connection lost"),
+			expectedErr: errors.New("connection lost")}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			repository, mock := newMockRepository(t)
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `tweets` SET `deleted_at`").WillReturnResult(sqlmock.NewResult(0, 1))
+			unpin := mock.ExpectExec("UPDATE `users` SET `pinned_tweet_id`").WithArgs(nil, sqlmock.AnyArg(), 3)
+			if test.unpinErr != nil {
+				unpin.WillReturnError(test.unpinErr)
+				//the tweet stays, it would otherwise still be pinned
+				mock.ExpectRollback()
+			} else {
+				unpin.WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `bookmarks`").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			}
+
+			err := repository.DeleteTweet(context.Background(), 3)
+
+			assert.Equal(t, test.expectedErr, err)
+		})
+	}
+}
+
+func TestGetTweetsOfUserAfterRename(t *testing.T) {
+	repository, mock := newMockRepository(t)
+	//abc was renamed to xyz, the tweets only know the user id
+	mock.ExpectQuery("SELECT \\* FROM `users` WHERE BINARY name = \\?").WithArgs("xyz").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "xyz"))
+	mock.ExpectQuery("SELECT tweets.\\*, users.name AS user_name FROM `tweets` JOIN users ON users.id = tweets.user_id WHERE tweets.user_id = \\?").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "user_name"}).AddRow(10, 1, "hello", "xyz"))
+	mock.ExpectQuery("SELECT \\* FROM `media`").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("SELECT \\* FROM `polls`").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	tweets, err := repository.GetTweetsOfUser(context.Background(), "xyz")
+
+	assert.NoError(t, err)
+	assert.Len(t, *tweets, 1)
+	assert.Equal(t, "xyz", (*tweets)[0].UserName)
+	assert.Equal(t, uint(1), (*tweets)[0].UserID)
+}
+
+func TestGetTweetsOfUserLookupFails(t *testing.T) {
+	repository, mock := newMockRepository(t)
+	mock.ExpectQuery("SELECT \\* FROM `users` WHERE BINARY name = \\?").WithArgs("abc").
+		WillReturnError(errors.New("connection lost"))
+
+	//no tweets are read for user id 0
+	tweets, err := repository.GetTweetsOfUser(context.Background(), "abc")
+
+	assert.EqualError(t, err, "connection lost")
+	assert.Nil(t, tweets)
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (repository *MySQLRepository) FindFollowCandidates(ctx context.Context, username string, limit int) (*[]models.Suggestion, error) {
+	db := repository.db.WithContext(ctx)
+	//friends of friends: users followed by the users username follows,
+	//minus username itself and everybody it already follows
+	var candidates []models.Suggestion
+	err := db.Raw(`
+		SELECT u.name AS candidate,
+			COUNT(DISTINCT f1.target_user_id) AS mutuals,
+			(SELECT COUNT(*) FROM follows f3 WHERE f3.target_user_id = f2.target_user_id and f3.deleted_at IS NULL) AS followers
+		FROM users me
+		JOIN follows f1 ON f1.source_user_id = me.id and f1.deleted_at IS NULL
+		JOIN follows f2 ON f2.source_user_id = f1.target_user_id and f2.deleted_at IS NULL
+		JOIN users u ON u.id = f2.target_user_id and u.deleted_at IS NULL and u.deactivated_at IS NULL
+		WHERE BINARY me.name = ? and me.deleted_at IS NULL
+			and f2.target_user_id <> me.id
+			and f2.target_user_id NOT IN (SELECT target_user_id FROM follows WHERE source_user_id = me.id and deleted_at IS NULL)
+		GROUP BY f2.target_user_id, u.name
+		ORDER BY mutuals DESC
+		LIMIT ?`, username, limit).Scan(&candidates).Error
+	return &candidates, err
+}
+
+func (repository *MySQLRepository) SaveSuggestions(ctx context.Context, username string, suggestions *[]models.Suggestion, computedAt time.Time) error {
+	db := repository.db.WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("BINARY user_name = ?", username).Delete(&models.Suggestion{}).Error
+		if err != nil {
+			return err
+		}
+		if len(*suggestions) > 0 {
+			err = tx.Create(suggestions).Error
+			if err != nil {
+				return err
+			}
+		}
+		state := models.SuggestionState{UserName: username, ComputedAt: computedAt, Stale: false}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"computed_at", "stale", "updated_at"}),
+		}).Create(&state).Error
+	})
+}
+
+func (repository *MySQLRepository) GetSuggestions(ctx context.Context, username string, limit int) (*[]models.Suggestion, error) {
+	db := repository.db.WithContext(ctx)
+	var suggestions []models.Suggestion
+	err := db.Where("BINARY user_name = ?", username).Order("score DESC").Limit(limit).Find(&suggestions).Error
+	return &suggestions, err
+}
+
+func (repository *MySQLRepository) GetSuggestionState(ctx context.Context, username string) (*models.SuggestionState, error) {
+	db := repository.db.WithContext(ctx)
+	//nil without an error means the suggestions were never computed
+	var state models.SuggestionState
+	result := db.Where("BINARY user_name = ?", username).Find(&state)
+	if result.Error != nil || result.RowsAffected != 1 {
+		return nil, result.Error
+	}
+	return &state, nil
+}
+
+func (repository *MySQLRepository) MarkSuggestionsStale(ctx context.Context, username string) error {
+	db := repository.db.WithContext(ctx)
+	//only users whose suggestions were computed before need a refresh
+	return db.Model(&models.SuggestionState{}).Where("BINARY user_name = ?", username).Update("stale", true).Error
+}
+
+func (repository *MySQLRepository) GetUsersWithStaleSuggestions(ctx context.Context, computedBefore time.Time, limit int) ([]string, error) {
+	db := repository.db.WithContext(ctx)
+	var usernames []string
+	err := db.Model(&models.SuggestionState{}).
+		Where("stale = ? or computed_at < ?", true, computedBefore).
+		Order("computed_at").
+		Limit(limit).
+		Pluck("user_name", &usernames).Error
+	return usernames, err
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+)
+
+// ImportTweet stores a tweet as it is, timestamps included. Unlike AddTweet
+// it accepts tweets of deactivated and disabled users.
+func (repository *MySQLRepository) ImportTweet(ctx context.Context, tweet *models.Tweet) error {
+	db := repository.db.WithContext(ctx)
+	var user models.User
+	rows := db.Where("BINARY name = ?", tweet.UserName).Find(&user).RowsAffected
+	if rows != 1 {
+		return fmt.Errorf("user %q: %w", tweet.UserName, ErrNotFound)
+	}
+	tweet.UserID = user.ID
+	return db.Create(tweet).Error
+}
+
+// ImportFollow stores a follow edge as it is, timestamps included.
+func (repository *MySQLRepository) ImportFollow(ctx context.Context, follow *models.Follows) error {
+	db := repository.db.WithContext(ctx)
+	var users []models.User
+	err := db.Where("BINARY name IN ?", []string{follow.SourceUser, follow.TargetUser}).Find(&users).Error
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if user.Name == follow.SourceUser {
+			follow.SourceUserID = user.ID
+		}
+		if user.Name == follow.TargetUser {
+			follow.TargetUserID = user.ID
+		}
+	}
+	if follow.SourceUserID == 0 {
+		return fmt.Errorf("user %q: %w", follow.SourceUser, ErrNotFound)
+	}
+	if follow.TargetUserID == 0 {
+		return fmt.Errorf("user %q: %w", follow.TargetUser, ErrNotFound)
+	}
+	err = db.Create(follow).Error
+	return conflict(err, fmt.Sprintf("follow of %q", follow.TargetUser))
+}
+
+func (repository *MySQLRepository) ExportUsers(ctx context.Context, afterID uint, limit int) (*[]models.User, error) {
+	db := repository.db.WithContext(ctx)
+	var users []models.User
+	err := db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&users).Error
+	return &users, err
+}
+
+func (repository *MySQLRepository) ExportTweets(ctx context.Context, afterID uint, limit int) (*[]models.Tweet, error) {
+	db := repository.db.WithContext(ctx)
+	var tweets []models.Tweet
+	err := db.Scopes(tweetsWithAuthor).Where("tweets.id > ?", afterID).Order("tweets.id").Limit(limit).Find(&tweets).Error
+	return &tweets, err
+}
+
+func (repository *MySQLRepository) ExportFollows(ctx context.Context, afterID uint, limit int) (*[]models.Follows, error) {
+	db := repository.db.WithContext(ctx)
+	var follows []models.Follows
+	err := db.Scopes(followsWithNames).Where("follows.id > ?", afterID).Order("follows.id").Limit(limit).Find(&follows).Error
+	return &follows, err
+}
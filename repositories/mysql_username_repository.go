@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RenameUser changes the handle of a user. The old handle is kept in the
+// history until now+cooldown so that it redirects and cannot be squatted.
+func (repository *MySQLRepository) RenameUser(ctx context.Context, username string, newname string, now time.Time, cooldown time.Duration) error {
+	db := repository.db.WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		rows := tx.Where("BINARY name = ? and deactivated_at IS NULL", username).Find(&user).RowsAffected
+		if rows != 1 {
+			return fmt.Errorf("active user %q: %w", username, ErrNotFound)
+		}
+		var taken models.User
+		rows = tx.Unscoped().Where("BINARY name = ?", newname).Find(&taken).RowsAffected
+		if rows != 0 {
+			return fmt.Errorf("name %q: %w", newname, ErrConflict)
+		}
+		//a reserved handle can only be taken back by the user who gave it up
+		var reserved models.UsernameHistory
+		rows = tx.Where("BINARY old_name = ? and released_at > ? and user_id <> ?", newname, now, user.ID).Find(&reserved).RowsAffected
+		if rows != 0 {
+			return fmt.Errorf("name %q: %w", newname, ErrConflict)
+		}
+		err := tx.Where("BINARY old_name = ? and user_id = ?", newname, user.ID).Delete(&models.UsernameHistory{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&models.UsernameHistory{UserID: user.ID, OldName: username, ReleasedAt: now.Add(cooldown)}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&user).Update("name", newname).Error
+		if err != nil {
+			return conflict(err, fmt.Sprintf("name %q", newname))
+		}
+
+		//tweets and follows reference the user by id and need nothing, the
+		//other tables still store the handle and are kept in step
+		updates := []struct {
+			model  interface{}
+			column string
+			query  string
+			arg    interface{}
+		}{
+			{&models.Media{}, "user_name", "BINARY user_name = ?", username},
+			{&models.Draft{}, "user_name", "BINARY user_name = ?", username},
+			{&models.Bookmark{}, "user_name", "BINARY user_name = ?", username},
+			{&models.BookmarkFolder{}, "user_name", "BINARY user_name = ?", username},
+			{&models.List{}, "owner_name", "BINARY owner_name = ?", username},
+			{&models.ListMember{}, "user_name", "BINARY user_name = ?", username},
+			{&models.ListSubscription{}, "user_name", "BINARY user_name = ?", username},
+			{&models.PollVote{}, "user_name", "BINARY user_name = ?", username},
+			{&models.Suggestion{}, "user_name", "BINARY user_name = ?", username},
+			{&models.Suggestion{}, "candidate", "BINARY candidate = ?", username},
+			{&models.SuggestionState{}, "user_name", "BINARY user_name = ?", username},
+			{&models.DataExport{}, "user_name", "BINARY user_name = ?", username},
+		}
+		for _, u := range updates {
+			err = tx.Unscoped().Model(u.model).Where(u.query, u.arg).Update(u.column, newname).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetRenamedUsername returns the current handle of the user who gave up
+// oldname, or an empty string when oldname does not redirect anywhere.
+func (repository *MySQLRepository) GetRenamedUsername(ctx context.Context, oldname string, now time.Time) (string, error) {
+	db := repository.db.WithContext(ctx)
+	var names []string
+	err := db.Model(&models.User{}).
+		Joins("JOIN username_histories ON username_histories.user_id = users.id and username_histories.deleted_at IS NULL").
+		Where("BINARY username_histories.old_name = ? and username_histories.released_at > ?", oldname, now).
+		Limit(1).
+		Pluck("users.name", &names).Error
+	if err != nil || len(names) == 0 {
+		return "", err
+	}
+	return names[0], nil
+}
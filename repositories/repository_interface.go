@@ -1,19 +1,92 @@
 package repositories
 
 import (
+	"context"
 	"example/layered-architecture/models"
+	"time"
 )
 
 //go:generate mockgen --destination=./mock_repository_interface.go --package=repositories example/layered-architecture/repositories RepositoryInterface
 type RepositoryInterface interface {
-	AddUser(user *models.User) error
-	SignIn(user *models.User) error
-	GetAllUsers() (*[]models.User, error)
-	AddTweet(tweet *models.Tweet) error
-	GetTweetsOfUser(username string) (*[]models.Tweet, error)
-	GetFolloweesOfUser(username string) (*[]models.Follows, error)
-	AddFollowee(follow *models.Follows) error
-	DeleteTweet(tweetid int) error
-	DeleteFollowee(username string, followeename string) error
-	CheckFollowing(username string, followeename string) error
+	// WithinTransaction runs fn with a repository whose methods all share one
+	// transaction. It commits when fn returns nil and rolls back otherwise.
+	WithinTransaction(ctx context.Context, fn func(repository RepositoryInterface) error) error
+	AddUser(ctx context.Context, user *models.User) error
+	SignIn(ctx context.Context, user *models.User) error
+	GetAllUsers(ctx context.Context) (*[]models.User, error)
+	AddTweet(ctx context.Context, tweet *models.Tweet) error
+	GetTweetsOfUser(ctx context.Context, username string) (*[]models.Tweet, error)
+	GetFolloweesOfUser(ctx context.Context, username string) (*[]models.Follows, error)
+	AddFollowee(ctx context.Context, follow *models.Follows) error
+	DeleteTweet(ctx context.Context, tweetid int) error
+	DeleteFollowee(ctx context.Context, username string, followeename string) error
+	GetRelationship(ctx context.Context, source string, target string) (*models.Relationship, error)
+	AddMedia(ctx context.Context, media *models.Media) error
+	GetMedia(ctx context.Context, mediaid int) (*models.Media, error)
+	AddDraft(ctx context.Context, draft *models.Draft) error
+	GetDraftsOfUser(ctx context.Context, username string) (*[]models.Draft, error)
+	GetDraft(ctx context.Context, draftid int) (*models.Draft, error)
+	UpdateDraft(ctx context.Context, draft *models.Draft) error
+	DeleteDraft(ctx context.Context, draftid int) error
+	ClaimDueDrafts(ctx context.Context, owner string, now time.Time, lease time.Duration, limit int) (*[]models.Draft, error)
+	MarkDraftPublished(ctx context.Context, draftid uint) error
+	MarkDraftFailed(ctx context.Context, draftid uint, reason string) error
+	PinTweet(ctx context.Context, username string, tweetid int) error
+	UnpinTweet(ctx context.Context, username string) error
+	AddBookmark(ctx context.Context, bookmark *models.Bookmark) error
+	DeleteBookmark(ctx context.Context, username string, tweetid int) error
+	GetBookmarks(ctx context.Context, username string, folderid *uint, after uint, limit int) (*[]models.Bookmark, error)
+	AddBookmarkFolder(ctx context.Context, folder *models.BookmarkFolder) error
+	GetBookmarkFolders(ctx context.Context, username string) (*[]models.BookmarkFolder, error)
+	DeleteBookmarkFolder(ctx context.Context, username string, folderid int) error
+	AddList(ctx context.Context, list *models.List) error
+	GetList(ctx context.Context, listid int) (*models.List, error)
+	GetListsOfUser(ctx context.Context, username string) (*[]models.List, error)
+	DeleteList(ctx context.Context, listid int) error
+	AddListMember(ctx context.Context, member *models.ListMember) error
+	DeleteListMember(ctx context.Context, listid int, username string) error
+	GetListMembers(ctx context.Context, listid int) (*[]models.ListMember, error)
+	SubscribeList(ctx context.Context, subscription *models.ListSubscription) error
+	UnsubscribeList(ctx context.Context, listid int, username string) error
+	GetListTimeline(ctx context.Context, listid int, limit int) (*[]models.Tweet, error)
+	GetPoll(ctx context.Context, tweetid int) (*models.Poll, error)
+	AddPollVote(ctx context.Context, vote *models.PollVote, now time.Time) error
+	GetPollVote(ctx context.Context, pollid uint, username string) (*models.PollVote, error)
+	CountPollVotes(ctx context.Context, pollid uint) (map[uint]int, error)
+	FindFollowCandidates(ctx context.Context, username string, limit int) (*[]models.Suggestion, error)
+	SaveSuggestions(ctx context.Context, username string, suggestions *[]models.Suggestion, computedAt time.Time) error
+	GetSuggestions(ctx context.Context, username string, limit int) (*[]models.Suggestion, error)
+	GetSuggestionState(ctx context.Context, username string) (*models.SuggestionState, error)
+	MarkSuggestionsStale(ctx context.Context, username string) error
+	GetUsersWithStaleSuggestions(ctx context.Context, computedBefore time.Time, limit int) ([]string, error)
+	DeactivateUser(ctx context.Context, username string, now time.Time) error
+	ReactivateUser(ctx context.Context, username string) error
+	GetUsersToPurge(ctx context.Context, deactivatedBefore time.Time, after *models.User, limit int) ([]models.User, error)
+	PurgeUser(ctx context.Context, username string) ([]string, error)
+	RenameUser(ctx context.Context, username string, newname string, now time.Time, cooldown time.Duration) error
+	GetRenamedUsername(ctx context.Context, oldname string, now time.Time) (string, error)
+	DisableUser(ctx context.Context, username string, now time.Time) error
+	EnableUser(ctx context.Context, username string) error
+	SetPassword(ctx context.Context, username string, password string) error
+	ListUsers(ctx context.Context) (*[]models.User, error)
+	GetStats(ctx context.Context) (*models.Stats, error)
+	RepairFollows(ctx context.Context, dryRun bool) (*models.FollowRepair, error)
+	ImportTweet(ctx context.Context, tweet *models.Tweet) error
+	ImportFollow(ctx context.Context, follow *models.Follows) error
+	ExportUsers(ctx context.Context, afterID uint, limit int) (*[]models.User, error)
+	ExportTweets(ctx context.Context, afterID uint, limit int) (*[]models.Tweet, error)
+	ExportFollows(ctx context.Context, afterID uint, limit int) (*[]models.Follows, error)
+	AddDataExport(ctx context.Context, export *models.DataExport) error
+	GetLatestDataExport(ctx context.Context, username string) (*models.DataExport, error)
+	GetDataExportByToken(ctx context.Context, token string) (*models.DataExport, error)
+	ClaimPendingDataExports(ctx context.Context, owner string, now time.Time, lease time.Duration, limit int) (*[]models.DataExport, error)
+	MarkDataExportReady(ctx context.Context, exportid uint, blobKey string, token string, expiresAt time.Time) error
+	MarkDataExportFailed(ctx context.Context, exportid uint, reason string, expiresAt time.Time) error
+	GetExpiredDataExports(ctx context.Context, now time.Time, limit int) (*[]models.DataExport, error)
+	DeleteDataExport(ctx context.Context, exportid uint) error
+	GetUser(ctx context.Context, username string) (*models.User, error)
+	GetUsernameHistory(ctx context.Context, username string) (*[]models.UsernameHistory, error)
+	GetFollowersOfUser(ctx context.Context, username string) (*[]models.Follows, error)
+	GetMediaOfUser(ctx context.Context, username string) (*[]models.Media, error)
+	GetPollVotesOfUser(ctx context.Context, username string) (*[]models.PollVote, error)
 }
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var tracer = otel.Tracer("example/layered-architecture/repositories")
+
+const spanKey = "tracing:span"
+
+// startSpan starts a span for a statement, named after the repository
+// method running it.
+func startSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		method := repositoryMethod()
+		_, span := tracer.Start(db.Statement.Context, method+" "+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemMySQL,
+				semconv.DBOperationName(operation),
+				attribute.String("repository.method", method),
+			))
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+// endSpan ends the span of a statement. The statement is recorded with its
+// placeholders, its values may hold passwords.
+func endSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(spanKey)
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		span.SetAttributes(
+			semconv.DBQueryText(db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.RowsAffected),
+			semconv.DBCollectionName(db.Statement.Table),
+		)
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+		span.End()
+	}
+}
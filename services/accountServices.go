@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const purgeBatchSize = 100
+
+// DeactivateUser starts the deletion of an account. The password has to be
+// confirmed, and the account is only removed for good by the AccountPurger
+// once the grace period is over.
+func (service *UserService) DeactivateUser(ctx context.Context, user *models.User) error {
+	return translate(service.repository.WithinTransaction(ctx, func(repository repositories.RepositoryInterface) error {
+		err := repository.SignIn(ctx, user)
+		if err != nil {
+			return err
+		}
+		return repository.DeactivateUser(ctx, user.Name, time.Now())
+	}))
+}
+
+// PurgeDeactivatedUsers hard deletes the accounts that were deactivated
+// before the cutoff and returns how many were removed. The accounts are read
+// in pages, each starting after the last account of the one before. An
+// account that cannot be purged is logged and skipped, so that it does not
+// hold up the ones after it; the error then only says how many were left.
+func (service *UserService) PurgeDeactivatedUsers(ctx context.Context, deactivatedBefore time.Time) (int, error) {
+	purged := 0
+	failed := 0
+	var after *models.User
+	for {
+		users, err := service.repository.GetUsersToPurge(ctx, deactivatedBefore, after, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		for _, user := range users {
+			blobKeys, err := service.repository.PurgeUser(ctx, user.Name)
+			if err != nil {
+				slog.ErrorContext(ctx, "cannot purge account", "user", user.Name, "error", err)
+				failed++
+				continue
+			}
+			purged++
+			//the rows are gone, a file left behind is only wasted space
+			for _, key := range blobKeys {
+				if service.blobs != nil {
+					service.blobs.Delete(key)
+				}
+			}
+		}
+		if len(users) < purgeBatchSize {
+			break
+		}
+		after = &users[len(users)-1]
+	}
+	if failed > 0 {
+		return purged, fmt.Errorf("%d of %d accounts could not be purged", failed, purged+failed)
+	}
+	return purged, nil
+}
+
+// AccountPurger removes deactivated accounts once their grace period is over.
+type AccountPurger struct {
+	service   *UserService
+	interval  time.Duration
+	grace     time.Duration
+	heartbeat Heartbeat
+}
+
+func NewAccountPurger(service *UserService, interval time.Duration, grace time.Duration) *AccountPurger {
+	return &AccountPurger{service: service, interval: interval, grace: grace}
+}
+
+// Run purges expired accounts every interval until ctx is cancelled.
+func (purger *AccountPurger) Run(ctx context.Context) {
+	purger.heartbeat.start(purger.interval)
+	defer purger.heartbeat.stop()
+	ticker := time.NewTicker(purger.interval)
+	defer ticker.Stop()
+	for {
+		roundCtx, span := startRound(ctx, "accountpurge")
+		_, err := purger.service.PurgeDeactivatedUsers(roundCtx, time.Now().Add(-purger.grace))
+		if err != nil {
+			slog.ErrorContext(roundCtx, "cannot purge deactivated accounts", "error", err)
+		}
+		end(span, err)
+		purger.heartbeat.beat()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Heartbeat tells whether the purger is still purging accounts.
+func (purger *AccountPurger) Heartbeat() *Heartbeat {
+	return &purger.heartbeat
+}
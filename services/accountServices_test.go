@@ -0,0 +1,132 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"example/layered-architecture/storage"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeactivateUser(t *testing.T) {
+	type testCase struct {
+		name                string
+		returnErrorOnSignIn error
+		expectedCalls       int
+	}
+	testCases := []testCase{{name: "wrong password",
+		returnErrorOnSignIn: errors.New("bad request"),
+		expectedCalls:       0},
+		{name: "success",
+			returnErrorOnSignIn: nil,
+			expectedCalls:       1}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			user := models.User{Name: "abc", Password: "secret"}
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			expectTransactions(mockRepository)
+			mockRepository.EXPECT().SignIn(gomock.Any(), &user).Return(test.returnErrorOnSignIn).Times(1)
+			mockRepository.
+				EXPECT().
+				DeactivateUser(gomock.Any(), "abc", gomock.Any()).
+				Return(nil).
+				Times(test.expectedCalls)
+			ms := NewUserService(mockRepository, nil)
+
+			err := ms.DeactivateUser(context.Background(), &user)
+
+			assert.Equal(t, test.returnErrorOnSignIn, err)
+		})
+	}
+}
+
+func TestSignInReactivates(t *testing.T) {
+	user := models.User{Name: "abc", Password: "secret"}
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.EXPECT().SignIn(gomock.Any(), &user).Return(nil).Times(1)
+	mockRepository.EXPECT().ReactivateUser(gomock.Any(), "abc").Return(nil).Times(1)
+	ms := NewUserService(mockRepository, nil)
+
+	err := ms.SignIn(context.Background(), &user)
+
+	assert.Nil(t, err)
+}
+
+func TestPurgeDeactivatedUsers(t *testing.T) {
+	cutoff := time.Now()
+	blobs, _ := storage.NewLocalBlobStore(t.TempDir())
+	blobs.Put("media/a", bytes.NewReader([]byte("picture")))
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		GetUsersToPurge(gomock.Any(), cutoff, gomock.Nil(), purgeBatchSize).
+		Return([]models.User{{Name: "abc"}, {Name: "def"}}, nil).
+		Times(1)
+	mockRepository.EXPECT().PurgeUser(gomock.Any(), "abc").Return([]string{"media/a"}, nil).Times(1)
+	mockRepository.EXPECT().PurgeUser(gomock.Any(), "def").Return(nil, nil).Times(1)
+	ms := NewUserService(mockRepository, blobs)
+
+	purged, err := ms.PurgeDeactivatedUsers(context.Background(), cutoff)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, purged)
+	_, err = blobs.Get("media/a")
+	assert.Equal(t, storage.ErrBlobNotFound, err)
+}
+
+func TestPurgeDeactivatedUsersSkipsFailures(t *testing.T) {
+	cutoff := time.Now()
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		GetUsersToPurge(gomock.Any(), cutoff, gomock.Nil(), purgeBatchSize).
+		Return([]models.User{{Name: "abc"}, {Name: "def"}}, nil).
+		Times(1)
+	mockRepository.EXPECT().PurgeUser(gomock.Any(), "abc").Return(nil, errors.New("lock wait timeout")).Times(1)
+	//the failing account does not keep the next one from being purged
+	mockRepository.EXPECT().PurgeUser(gomock.Any(), "def").Return(nil, nil).Times(1)
+	ms := NewUserService(mockRepository, nil)
+
+	purged, err := ms.PurgeDeactivatedUsers(context.Background(), cutoff)
+
+	assert.EqualError(t, err, "1 of 2 accounts could not be purged")
+	assert.Equal(t, 1, purged)
+}
+
+func TestPurgeDeactivatedUsersPastFailedBatch(t *testing.T) {
+	cutoff := time.Now()
+	deactivatedAt := cutoff.Add(-time.Hour)
+	failing := make([]models.User, purgeBatchSize)
+	for i := range failing {
+		failing[i] = models.User{Name: fmt.Sprintf("user%d", i), DeactivatedAt: &deactivatedAt}
+		failing[i].ID = uint(i + 1)
+	}
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		GetUsersToPurge(gomock.Any(), cutoff, gomock.Nil(), purgeBatchSize).
+		Return(failing, nil).
+		Times(1)
+	mockRepository.EXPECT().PurgeUser(gomock.Any(), gomock.Any()).Return(nil, errors.New("lock wait timeout")).Times(purgeBatchSize)
+	//the next page starts after the failed ones instead of reading them again
+	mockRepository.
+		EXPECT().
+		GetUsersToPurge(gomock.Any(), cutoff, &failing[purgeBatchSize-1], purgeBatchSize).
+		Return([]models.User{{Name: "abc"}}, nil).
+		Times(1)
+	mockRepository.EXPECT().PurgeUser(gomock.Any(), "abc").Return(nil, nil).Times(1)
+	ms := NewUserService(mockRepository, nil)
+
+	purged, err := ms.PurgeDeactivatedUsers(context.Background(), cutoff)
+
+	assert.EqualError(t, err, fmt.Sprintf("%d of %d accounts could not be purged", purgeBatchSize, purgeBatchSize+1))
+	assert.Equal(t, 1, purged)
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"time"
+)
+
+// DisableUser locks an account: the user can no longer sign in, tweet or
+// follow. Nothing is deleted and signing in does not undo it.
+func (service *UserService) DisableUser(ctx context.Context, username string) error {
+	return translate(service.repository.DisableUser(ctx, username, time.Now()))
+}
+
+func (service *UserService) EnableUser(ctx context.Context, username string) error {
+	return translate(service.repository.EnableUser(ctx, username))
+}
+
+func (service *UserService) ResetPassword(ctx context.Context, username string, password string) error {
+	//same policy as signing up
+	v := validation{}
+	v.check("password", password, passwordRules(username)...)
+	err := v.err("invalid password")
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.SetPassword(ctx, username, password))
+}
+
+// ListUsers returns every account, unlike GetAllUsers also those pending
+// deletion.
+func (service *UserService) ListUsers(ctx context.Context) (*[]models.User, error) {
+	return translated(service.repository.ListUsers(ctx))
+}
+
+func (service *UserService) GetStats(ctx context.Context) (*models.Stats, error) {
+	return translated(service.repository.GetStats(ctx))
+}
+
+// RepairFollows removes self-follows, or only counts them when dryRun is set.
+func (service *UserService) RepairFollows(ctx context.Context, dryRun bool) (*models.FollowRepair, error) {
+	return translated(service.repository.RepairFollows(ctx, dryRun))
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/layered-architecture/repositories"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetPassword(t *testing.T) {
+	type testCase struct {
+		name                        string
+		password                    string
+		expectedCalls               int
+		returnedErrorFromRepository error
+		expectedError               bool
+	}
+	testCases := []testCase{{name: "too short",
+		password:      "ab",
+		expectedCalls: 0,
+		expectedError: true},
+		{name: "no digit",
+			password:      "secretive",
+			expectedCalls: 0,
+			expectedError: true},
+		{name: "unknown user",
+			password:                    "secret42",
+			expectedCalls:               1,
+			returnedErrorFromRepository: errors.New("bad request"),
+			expectedError:               true},
+		{name: "success",
+			password:      "secret42",
+			expectedCalls: 1}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.
+				EXPECT().
+				SetPassword(gomock.Any(), "abc", test.password).
+				Return(test.returnedErrorFromRepository).
+				Times(test.expectedCalls)
+			ms := NewUserService(mockRepository, nil)
+
+			err := ms.ResetPassword(context.Background(), "abc", test.password)
+
+			assert.Equal(t, test.expectedError, err != nil)
+		})
+	}
+}
+
+func TestDisableUser(t *testing.T) {
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.EXPECT().DisableUser(gomock.Any(), "abc", gomock.Any()).Return(nil).Times(1)
+	ms := NewUserService(mockRepository, nil)
+
+	err := ms.DisableUser(context.Background(), "abc")
+
+	assert.Nil(t, err)
+}
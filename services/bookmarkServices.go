@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"strconv"
+)
+
+const (
+	defaultBookmarkPageSize = 20
+	maxBookmarkPageSize     = 100
+)
+
+func (service *UserService) AddBookmark(ctx context.Context, bookmark *models.Bookmark) error {
+	return translate(service.repository.AddBookmark(ctx, bookmark))
+}
+
+func (service *UserService) DeleteBookmark(ctx context.Context, username string, tweetid int) error {
+	return translate(service.repository.DeleteBookmark(ctx, username, tweetid))
+}
+
+// GetBookmarks returns a page of the user's bookmarks, newest first. The
+// cursor is the NextCursor of the previous page, or empty for the first one.
+func (service *UserService) GetBookmarks(ctx context.Context, username string, folderid *uint, cursor string, limit int) (*models.BookmarkPage, error) {
+	var after uint64
+	if cursor != "" {
+		var err error
+		after, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, ValidationError("invalid cursor", map[string]string{"cursor": "use the nextcursor of the previous page"})
+		}
+	}
+	if limit <= 0 || limit > maxBookmarkPageSize {
+		limit = defaultBookmarkPageSize
+	}
+	//fetch one extra row to know whether there is a next page
+	bookmarks, err := service.repository.GetBookmarks(ctx, username, folderid, uint(after), limit+1)
+	if err != nil {
+		return nil, translate(err)
+	}
+	page := models.BookmarkPage{Bookmarks: *bookmarks}
+	if len(page.Bookmarks) > limit {
+		page.Bookmarks = page.Bookmarks[:limit]
+		page.NextCursor = strconv.FormatUint(uint64(page.Bookmarks[limit-1].ID), 10)
+	}
+	return &page, nil
+}
+
+func (service *UserService) AddBookmarkFolder(ctx context.Context, folder *models.BookmarkFolder) error {
+	err := validateBookmarkFolder(folder)
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.AddBookmarkFolder(ctx, folder))
+}
+
+func (service *UserService) GetBookmarkFolders(ctx context.Context, username string) (*[]models.BookmarkFolder, error) {
+	return translated(service.repository.GetBookmarkFolders(ctx, username))
+}
+
+func (service *UserService) DeleteBookmarkFolder(ctx context.Context, username string, folderid int) error {
+	return translate(service.repository.DeleteBookmarkFolder(ctx, username, folderid))
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetBookmarks(t *testing.T) {
+	type testCase struct {
+		name                 string
+		cursor               string
+		expectedAfter        uint
+		returnFromRepository []models.Bookmark
+		expectedSize         int
+		expectedNextCursor   string
+	}
+	testCases := []testCase{{name: "first page with more to come",
+		cursor:        "",
+		expectedAfter: 0,
+		returnFromRepository: []models.Bookmark{{Model: gorm.Model{ID: 9}},
+			{Model: gorm.Model{ID: 8}}, {Model: gorm.Model{ID: 7}}},
+		expectedSize:       2,
+		expectedNextCursor: "8"},
+		{name: "last page",
+			cursor:               "8",
+			expectedAfter:        8,
+			returnFromRepository: []models.Bookmark{{Model: gorm.Model{ID: 7}}},
+			expectedSize:         1,
+			expectedNextCursor:   ""}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.
+				EXPECT().
+				GetBookmarks(gomock.Any(), "abc", nil, test.expectedAfter, 3).
+				Return(&test.returnFromRepository, nil).
+				Times(1)
+			ms := NewUserService(mockRepository, nil)
+
+			page, err := ms.GetBookmarks(context.Background(), "abc", nil, test.cursor, 2)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedSize, len(page.Bookmarks))
+			assert.Equal(t, test.expectedNextCursor, page.NextCursor)
+		})
+	}
+}
+
+func TestGetBookmarksInvalidCursor(t *testing.T) {
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	ms := NewUserService(mockRepository, nil)
+
+	_, err := ms.GetBookmarks(context.Background(), "abc", nil, "not-a-cursor", 2)
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,441 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/storage"
+	"html/template"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const (
+	// dataExportLifetime is how long an archive can be downloaded once it is
+	// ready, and how long a failed export is shown before it is removed.
+	dataExportLifetime = 7 * 24 * time.Hour
+	// dataExportClaimLease is how long a worker owns an export it is building.
+	dataExportClaimLease   = 30 * time.Minute
+	dataExportBatchSize    = 10
+	dataExportBookmarkPage = 500
+	// dataExportFailure is what the user is told about a failed export, the
+	// error itself is only logged.
+	dataExportFailure = "the export could not be built, request it again"
+)
+
+// RequestDataExport queues an archive of everything stored about username.
+// While an earlier request is still being built or can still be downloaded,
+// that one is returned instead, so that repeated requests do not fill the
+// blob store with archives.
+func (service *UserService) RequestDataExport(ctx context.Context, username string) (*models.DataExport, error) {
+	latest, err := service.repository.GetLatestDataExport(ctx, username)
+	if err == nil && latest.Status == models.DataExportPending {
+		return latest, nil
+	}
+	if err == nil && latest.Status == models.DataExportReady && latest.ExpiresAt != nil && latest.ExpiresAt.After(time.Now()) {
+		latest.DownloadURL = "/api/exports/" + latest.Token
+		return latest, nil
+	}
+	export := models.DataExport{UserName: username, Status: models.DataExportPending}
+	err = service.repository.AddDataExport(ctx, &export)
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &export, nil
+}
+
+// GetDataExport returns the latest export of username, with a download link
+// once it is ready.
+func (service *UserService) GetDataExport(ctx context.Context, username string) (*models.DataExport, error) {
+	export, err := service.repository.GetLatestDataExport(ctx, username)
+	if err != nil {
+		return nil, translate(err)
+	}
+	if export.Status == models.DataExportReady {
+		export.DownloadURL = "/api/exports/" + export.Token
+	}
+	return export, nil
+}
+
+// OpenDataExport opens a ready archive by its download token. The token is
+// the only credential, so it stops working when the archive expires.
+func (service *UserService) OpenDataExport(ctx context.Context, token string) (io.ReadCloser, error) {
+	export, err := service.repository.GetDataExportByToken(ctx, token)
+	if err != nil {
+		return nil, translate(err)
+	}
+	if export.ExpiresAt == nil || !export.ExpiresAt.After(time.Now()) {
+		return nil, NotFoundError("export expired")
+	}
+	return service.blobs.Get(export.BlobKey)
+}
+
+// BuildDataExports builds pending archives and returns how many became
+// ready. Like PublishDueDrafts it claims its work first, so several server
+// instances can run it at the same time.
+func (service *UserService) BuildDataExports(ctx context.Context, now time.Time) (int, error) {
+	owner, err := randomKey()
+	if err != nil {
+		return 0, err
+	}
+	exports, err := service.repository.ClaimPendingDataExports(ctx, owner, now, dataExportClaimLease, dataExportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	built := 0
+	for _, export := range *exports {
+		expiresAt := time.Now().Add(dataExportLifetime)
+		key, buildErr := service.buildDataExport(ctx, export.UserName)
+		if buildErr != nil {
+			slog.ErrorContext(ctx, "cannot build data export", "export", export.ID, "user", export.UserName, "error", buildErr)
+			err = service.repository.MarkDataExportFailed(ctx, export.ID, dataExportFailure, expiresAt)
+			if err != nil {
+				return built, err
+			}
+			continue
+		}
+		token, err := randomKey()
+		if err == nil {
+			err = service.repository.MarkDataExportReady(ctx, export.ID, key, token, expiresAt)
+		}
+		if err != nil {
+			service.blobs.Delete(key)
+			return built, err
+		}
+		built++
+	}
+	return built, nil
+}
+
+// CleanUpDataExports removes expired archives and returns how many were
+// removed.
+func (service *UserService) CleanUpDataExports(ctx context.Context, now time.Time) (int, error) {
+	exports, err := service.repository.GetExpiredDataExports(ctx, now, dataExportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, export := range *exports {
+		if export.BlobKey != "" {
+			err = service.blobs.Delete(export.BlobKey)
+			if err != nil {
+				return removed, err
+			}
+		}
+		err = service.repository.DeleteDataExport(ctx, export.ID)
+		if err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// buildDataExport streams the archive of username into the blob store and
+// returns its key.
+func (service *UserService) buildDataExport(ctx context.Context, username string) (string, error) {
+	id, err := randomKey()
+	if err != nil {
+		return "", err
+	}
+	key := "exports/" + id + ".zip"
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(service.writeDataArchive(ctx, writer, username))
+	}()
+	err = service.blobs.Put(key, reader)
+	//unblocks the writer if the store gave up early
+	reader.Close()
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// profileRecord is the user without the password.
+type profileRecord struct {
+	Name          string                   `json:"name"`
+	CreatedAt     time.Time                `json:"createdat"`
+	PinnedTweetID *uint                    `json:"pinnedtweetid"`
+	DeactivatedAt *time.Time               `json:"deactivatedat,omitempty"`
+	DisabledAt    *time.Time               `json:"disabledat,omitempty"`
+	PreviousNames []models.UsernameHistory `json:"previousnames"`
+}
+
+// dataArchive is everything that goes into an archive. Poll votes are the
+// closest this service has to likes; direct messages and sessions are not
+// stored, so there is nothing to export for them. Reserved handles are the
+// previous names of the profile.
+type dataArchive struct {
+	Profile         profileRecord
+	Tweets          []models.Tweet
+	Followees       []models.Follows
+	Followers       []models.Follows
+	Bookmarks       []models.Bookmark
+	BookmarkFolders []models.BookmarkFolder
+	Drafts          []models.Draft
+	Lists           []models.List
+	Media           []models.Media
+	MediaFiles      map[uint]string
+	PollVotes       []models.PollVote
+}
+
+func (service *UserService) collectDataArchive(ctx context.Context, username string) (*dataArchive, error) {
+	user, err := service.repository.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	history, err := service.repository.GetUsernameHistory(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archive := dataArchive{
+		Profile: profileRecord{
+			Name:          user.Name,
+			CreatedAt:     user.CreatedAt,
+			PinnedTweetID: user.PinnedTweetID,
+			DeactivatedAt: user.DeactivatedAt,
+			DisabledAt:    user.DisabledAt,
+			PreviousNames: *history,
+		},
+		MediaFiles: map[uint]string{},
+	}
+	tweets, err := service.repository.GetTweetsOfUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archive.Tweets = *tweets
+	followees, err := service.repository.GetFolloweesOfUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archive.Followees = *followees
+	followers, err := service.repository.GetFollowersOfUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archive.Followers = *followers
+	var after uint
+	for {
+		bookmarks, err := service.repository.GetBookmarks(ctx, username, nil, after, dataExportBookmarkPage)
+		if err != nil {
+			return nil, err
+		}
+		archive.Bookmarks = append(archive.Bookmarks, *bookmarks...)
+		if len(*bookmarks) < dataExportBookmarkPage {
+			break
+		}
+		after = (*bookmarks)[len(*bookmarks)-1].ID
+	}
+	folders, err := service.repository.GetBookmarkFolders(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archive.BookmarkFolders = *folders
+	drafts, err := service.repository.GetDraftsOfUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archive.Drafts = *drafts
+	lists, err := service.repository.GetListsOfUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archive.Lists = *lists
+	votes, err := service.repository.GetPollVotesOfUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archive.PollVotes = *votes
+	media, err := service.repository.GetMediaOfUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	archive.Media = *media
+	for _, m := range archive.Media {
+		extension := ".jpg"
+		if m.MimeType == "image/png" {
+			extension = ".png"
+		}
+		archive.MediaFiles[m.ID] = "media/" + strconv.Itoa(int(m.ID)) + extension
+	}
+	return &archive, nil
+}
+
+// writeDataArchive writes the zip: one JSON file per kind of data, the media
+// files and an index.html to browse it all.
+func (service *UserService) writeDataArchive(ctx context.Context, w io.Writer, username string) error {
+	archive, err := service.collectDataArchive(ctx, username)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", archive.Profile},
+		{"tweets.json", archive.Tweets},
+		{"followees.json", archive.Followees},
+		{"followers.json", archive.Followers},
+		{"bookmarks.json", archive.Bookmarks},
+		{"bookmark_folders.json", archive.BookmarkFolders},
+		{"drafts.json", archive.Drafts},
+		{"lists.json", archive.Lists},
+		{"media.json", archive.Media},
+		{"poll_votes.json", archive.PollVotes},
+	}
+	for _, file := range files {
+		fw, err := zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(fw)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(file.data)
+		if err != nil {
+			return err
+		}
+	}
+	for _, m := range archive.Media {
+		err = service.copyBlob(zw, archive.MediaFiles[m.ID], m.BlobKey)
+		if err != nil {
+			return err
+		}
+	}
+	fw, err := zw.Create("index.html")
+	if err != nil {
+		return err
+	}
+	err = dataArchiveViewer.Execute(fw, archive)
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func (service *UserService) copyBlob(zw *zip.Writer, name string, key string) error {
+	blob, err := service.blobs.Get(key)
+	//a lost file should not keep the user from getting the rest
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, blob)
+	return err
+}
+
+var dataArchiveViewer = template.Must(template.New("index.html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Your data, @{{.Profile.Name}}</title>
+<style>
+body { font-family: sans-serif; max-width: 48em; margin: 2em auto; }
+table { border-collapse: collapse; width: 100%; }
+td, th { border-bottom: 1px solid #ddd; padding: .3em; text-align: left; vertical-align: top; }
+img { max-width: 12em; }
+</style>
+</head>
+<body>
+<h1>@{{.Profile.Name}}</h1>
+<p>Member since {{.Profile.CreatedAt.Format "2 January 2006"}}.
+{{range .Profile.PreviousNames}}Previously @{{.OldName}}, reserved for you until {{.ReleasedAt.Format "2 January 2006"}}. {{end}}</p>
+<p>The complete data is in the JSON files next to this page.</p>
+
+<h2>Tweets ({{len .Tweets}})</h2>
+<table>
+{{range .Tweets}}<tr><td>{{.CreatedAt.Format "2006-01-02 15:04"}}</td><td>{{.Content}}</td></tr>
+{{end}}</table>
+
+<h2>Following ({{len .Followees}})</h2>
+<ul>
+{{range .Followees}}<li>@{{.TargetUser}}</li>
+{{end}}</ul>
+
+<h2>Followers ({{len .Followers}})</h2>
+<ul>
+{{range .Followers}}<li>@{{.SourceUser}}</li>
+{{end}}</ul>
+
+<h2>Bookmarks ({{len .Bookmarks}})</h2>
+<table>
+{{range .Bookmarks}}{{if .Tweet}}<tr><td>@{{.Tweet.UserName}}</td><td>{{.Tweet.Content}}</td></tr>
+{{end}}{{end}}</table>
+
+<h2>Drafts ({{len .Drafts}})</h2>
+<table>
+{{range .Drafts}}<tr><td>{{.Content}}</td></tr>
+{{end}}</table>
+
+<h2>Lists ({{len .Lists}})</h2>
+<ul>
+{{range .Lists}}<li>{{.Title}}</li>
+{{end}}</ul>
+
+<h2>Poll votes ({{len .PollVotes}})</h2>
+<table>
+{{range .PollVotes}}<tr><td>{{.CreatedAt.Format "2006-01-02 15:04"}}</td><td>poll {{.PollID}}, option {{.OptionID}}</td></tr>
+{{end}}</table>
+
+<h2>Media ({{len .Media}})</h2>
+{{range .Media}}<a href="{{index $.MediaFiles .ID}}"><img src="{{index $.MediaFiles .ID}}" alt=""></a>
+{{end}}
+</body>
+</html>
+`))
+
+// DataExporter builds requested data exports and removes expired ones.
+type DataExporter struct {
+	service   *UserService
+	interval  time.Duration
+	heartbeat Heartbeat
+}
+
+func NewDataExporter(service *UserService, interval time.Duration) *DataExporter {
+	return &DataExporter{service: service, interval: interval}
+}
+
+// Run builds and cleans up exports every interval until ctx is cancelled.
+func (exporter *DataExporter) Run(ctx context.Context) {
+	exporter.heartbeat.start(exporter.interval)
+	defer exporter.heartbeat.stop()
+	ticker := time.NewTicker(exporter.interval)
+	defer ticker.Stop()
+	for {
+		roundCtx, span := startRound(ctx, "dataexports")
+		_, err := exporter.service.BuildDataExports(roundCtx, time.Now())
+		if err != nil {
+			slog.ErrorContext(roundCtx, "cannot build data exports", "error", err)
+			record(span, err)
+		}
+		_, err = exporter.service.CleanUpDataExports(roundCtx, time.Now())
+		if err != nil {
+			slog.ErrorContext(roundCtx, "cannot clean up data exports", "error", err)
+			record(span, err)
+		}
+		span.End()
+		exporter.heartbeat.beat()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Heartbeat tells whether the exporter is still building exports.
+func (exporter *DataExporter) Heartbeat() *Heartbeat {
+	return &exporter.heartbeat
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"example/layered-architecture/storage"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestDataExport(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	expired := time.Now().Add(-time.Hour)
+	type testCase struct {
+		name          string
+		latest        *models.DataExport
+		latestError   error
+		expectedCalls int
+	}
+	testCases := []testCase{{name: "first export",
+		latest:        &models.DataExport{},
+		latestError:   errors.New("record not found"),
+		expectedCalls: 1},
+		{name: "previous export expired",
+			latest:        &models.DataExport{Status: models.DataExportReady, ExpiresAt: &expired},
+			expectedCalls: 1},
+		{name: "previous export failed",
+			latest:        &models.DataExport{Status: models.DataExportFailed, ExpiresAt: &expires},
+			expectedCalls: 1},
+		{name: "previous export ready",
+			latest:        &models.DataExport{Status: models.DataExportReady, ExpiresAt: &expires, Token: "token"},
+			expectedCalls: 0},
+		{name: "still building",
+			latest:        &models.DataExport{Status: models.DataExportPending},
+			expectedCalls: 0}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.EXPECT().GetLatestDataExport(gomock.Any(), "abc").Return(test.latest, test.latestError).Times(1)
+			mockRepository.
+				EXPECT().
+				AddDataExport(gomock.Any(), &models.DataExport{UserName: "abc", Status: models.DataExportPending}).
+				Return(nil).
+				Times(test.expectedCalls)
+			ms := NewUserService(mockRepository, nil)
+
+			export, err := ms.RequestDataExport(context.Background(), "abc")
+
+			assert.Nil(t, err)
+			if test.expectedCalls == 0 {
+				//the earlier export is handed out again
+				assert.Equal(t, test.latest, export)
+			} else {
+				assert.Equal(t, models.DataExportPending, export.Status)
+			}
+		})
+	}
+}
+
+func TestBuildDataExports(t *testing.T) {
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	assert.Nil(t, err)
+	assert.Nil(t, blobs.Put("media/photo", bytes.NewReader([]byte("png"))))
+	export := models.DataExport{UserName: "abc", Status: models.DataExportPending}
+	export.ID = 3
+	media := models.Media{UserName: "abc", MimeType: "image/png", BlobKey: "media/photo"}
+	media.ID = 9
+
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.EXPECT().ClaimPendingDataExports(gomock.Any(), gomock.Any(), gomock.Any(), dataExportClaimLease, dataExportBatchSize).Return(&[]models.DataExport{export}, nil)
+	mockRepository.EXPECT().GetUser(gomock.Any(), "abc").Return(&models.User{Name: "abc", Password: "secret"}, nil)
+	mockRepository.EXPECT().GetUsernameHistory(gomock.Any(), "abc").Return(&[]models.UsernameHistory{{OldName: "old", ReleasedAt: time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)}}, nil)
+	mockRepository.EXPECT().GetTweetsOfUser(gomock.Any(), "abc").Return(&[]models.Tweet{{UserName: "abc", Content: "hello <b>"}}, nil)
+	mockRepository.EXPECT().GetFolloweesOfUser(gomock.Any(), "abc").Return(&[]models.Follows{}, nil)
+	mockRepository.EXPECT().GetFollowersOfUser(gomock.Any(), "abc").Return(&[]models.Follows{{SourceUser: "def", TargetUser: "abc"}}, nil)
+	mockRepository.EXPECT().GetBookmarks(gomock.Any(), "abc", nil, uint(0), dataExportBookmarkPage).Return(&[]models.Bookmark{}, nil)
+	mockRepository.EXPECT().GetBookmarkFolders(gomock.Any(), "abc").Return(&[]models.BookmarkFolder{}, nil)
+	mockRepository.EXPECT().GetDraftsOfUser(gomock.Any(), "abc").Return(&[]models.Draft{}, nil)
+	mockRepository.EXPECT().GetListsOfUser(gomock.Any(), "abc").Return(&[]models.List{}, nil)
+	mockRepository.EXPECT().GetPollVotesOfUser(gomock.Any(), "abc").Return(&[]models.PollVote{{PollID: 4, OptionID: 2, UserName: "abc"}}, nil)
+	mockRepository.EXPECT().GetMediaOfUser(gomock.Any(), "abc").Return(&[]models.Media{media}, nil)
+	var key string
+	mockRepository.
+		EXPECT().
+		MarkDataExportReady(gomock.Any(), uint(3), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, exportid uint, blobKey string, token string, expiresAt time.Time) error {
+			key = blobKey
+			assert.NotEmpty(t, token)
+			assert.True(t, expiresAt.After(time.Now()))
+			return nil
+		})
+	ms := NewUserService(mockRepository, blobs)
+
+	built, err := ms.BuildDataExports(context.Background(), time.Now())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, built)
+	blob, err := blobs.Get(key)
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+	contents := map[string]string{}
+	for _, file := range archive.File {
+		rc, _ := file.Open()
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[file.Name] = string(content)
+	}
+	assert.Contains(t, contents, "tweets.json")
+	assert.Equal(t, "png", contents["media/9.png"])
+	assert.NotContains(t, contents["profile.json"], "secret")
+	assert.Contains(t, contents["index.html"], "hello &lt;b&gt;")
+	assert.Contains(t, contents["index.html"], "@def")
+	assert.Contains(t, contents["poll_votes.json"], `"optionid": 2`)
+	assert.Contains(t, contents["index.html"], "@old, reserved for you until 2 January 2030")
+}
+
+func TestBuildDataExportsHidesError(t *testing.T) {
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	assert.Nil(t, err)
+	export := models.DataExport{UserName: "abc", Status: models.DataExportPending}
+	export.ID = 3
+
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.EXPECT().ClaimPendingDataExports(gomock.Any(), gomock.Any(), gomock.Any(), dataExportClaimLease, dataExportBatchSize).Return(&[]models.DataExport{export}, nil)
+	mockRepository.EXPECT().GetUser(gomock.Any(), "abc").Return(nil, errors.New("dial tcp 10.0.0.3:3306: connection refused"))
+	//the user sees the reason, so it must not be the internal error
+	mockRepository.EXPECT().MarkDataExportFailed(gomock.Any(), uint(3), dataExportFailure, gomock.Any()).Return(nil)
+	ms := NewUserService(mockRepository, blobs)
+
+	built, err := ms.BuildDataExports(context.Background(), time.Now())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, built)
+}
+
+func TestOpenDataExportExpired(t *testing.T) {
+	expired := time.Now().Add(-time.Minute)
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		GetDataExportByToken(gomock.Any(), "token").
+		Return(&models.DataExport{Status: models.DataExportReady, ExpiresAt: &expired, BlobKey: "exports/x.zip"}, nil)
+	ms := NewUserService(mockRepository, nil)
+
+	_, err := ms.OpenDataExport(context.Background(), "token")
+
+	assert.NotNil(t, err)
+}
+
+func TestCleanUpDataExports(t *testing.T) {
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	assert.Nil(t, err)
+	assert.Nil(t, blobs.Put("exports/x.zip", bytes.NewReader([]byte("zip"))))
+	ready := models.DataExport{Status: models.DataExportReady, BlobKey: "exports/x.zip"}
+	ready.ID = 1
+	failed := models.DataExport{Status: models.DataExportFailed}
+	failed.ID = 2
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.EXPECT().GetExpiredDataExports(gomock.Any(), gomock.Any(), dataExportBatchSize).Return(&[]models.DataExport{ready, failed}, nil)
+	mockRepository.EXPECT().DeleteDataExport(gomock.Any(), uint(1)).Return(nil)
+	mockRepository.EXPECT().DeleteDataExport(gomock.Any(), uint(2)).Return(nil)
+	ms := NewUserService(mockRepository, blobs)
+
+	removed, err := ms.CleanUpDataExports(context.Background(), time.Now())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, removed)
+	_, err = blobs.Get("exports/x.zip")
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+}
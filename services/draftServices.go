@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"time"
+)
+
+func (service *UserService) AddDraft(ctx context.Context, draft *models.Draft) error {
+	err := validateDraft(draft, time.Now())
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.AddDraft(ctx, draft))
+}
+
+func (service *UserService) GetDraftsOfUser(ctx context.Context, username string) (*[]models.Draft, error) {
+	return translated(service.repository.GetDraftsOfUser(ctx, username))
+}
+
+func (service *UserService) GetDraft(ctx context.Context, username string, draftid int) (*models.Draft, error) {
+	draft, err := service.repository.GetDraft(ctx, draftid)
+	if err != nil {
+		return nil, translate(err)
+	}
+	//drafts are private to their author
+	if draft.UserName != username {
+		return nil, NotFoundError("draft not found")
+	}
+	return draft, nil
+}
+
+func (service *UserService) UpdateDraft(ctx context.Context, username string, draft *models.Draft) error {
+	_, err := service.GetDraft(ctx, username, int(draft.ID))
+	if err != nil {
+		return err
+	}
+	err = validateDraft(draft, time.Now())
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.UpdateDraft(ctx, draft))
+}
+
+func (service *UserService) DeleteDraft(ctx context.Context, username string, draftid int) error {
+	_, err := service.GetDraft(ctx, username, draftid)
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.DeleteDraft(ctx, draftid))
+}
+
+// PublishDueDrafts publishes the scheduled drafts whose time has come and
+// returns how many were published. It claims the drafts first so that several
+// server instances can run it at the same time.
+func (service *UserService) PublishDueDrafts(ctx context.Context, now time.Time, limit int) (int, error) {
+	owner, err := randomKey()
+	if err != nil {
+		return 0, err
+	}
+	drafts, err := service.repository.ClaimDueDrafts(ctx, owner, now, draftClaimLease, limit)
+	if err != nil {
+		return 0, translate(err)
+	}
+	published := 0
+	for _, draft := range *drafts {
+		draftid := draft.ID
+		tweet := models.Tweet{UserName: draft.UserName, Content: draft.Content, DraftID: &draftid}
+		addErr := service.AddTweet(ctx, &tweet)
+		//the tweet may also exist from an earlier attempt that died before
+		//marking the draft, in which case this just records it
+		err = service.repository.MarkDraftPublished(ctx, draft.ID)
+		if err == nil {
+			published++
+			continue
+		}
+		if addErr != nil {
+			err = service.repository.MarkDraftFailed(ctx, draft.ID, addErr.Error())
+		}
+		if err != nil {
+			return published, err
+		}
+	}
+	return published, nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetDraft(t *testing.T) {
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		GetDraft(gomock.Any(), 1).
+		Return(&models.Draft{UserName: "abc"}, nil).
+		Times(2)
+	ms := NewUserService(mockRepository, nil)
+
+	_, err := ms.GetDraft(context.Background(), "abc", 1)
+	assert.Nil(t, err)
+	_, err = ms.GetDraft(context.Background(), "someone else", 1)
+	assert.NotNil(t, err)
+}
+
+func TestAddDraft(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.EXPECT().AddDraft(gomock.Any(), gomock.Any()).Times(0)
+	ms := NewUserService(mockRepository, nil)
+
+	err := ms.AddDraft(context.Background(), &models.Draft{UserName: "abc", Content: "later", PublishAt: &past})
+	assert.NotNil(t, err)
+}
+
+func TestPublishDueDrafts(t *testing.T) {
+	type testCase struct {
+		name                  string
+		returnErrorFromAdd    error
+		returnErrorFromMark   error
+		expectedFailedCalls   int
+		expectedPublishedSize int
+	}
+	testCases := []testCase{{name: "published",
+		returnErrorFromAdd:    nil,
+		returnErrorFromMark:   nil,
+		expectedFailedCalls:   0,
+		expectedPublishedSize: 1},
+		{name: "published by an earlier attempt",
+			returnErrorFromAdd:    errors.New("duplicate draft"),
+			returnErrorFromMark:   nil,
+			expectedFailedCalls:   0,
+			expectedPublishedSize: 1},
+		{name: "failed",
+			returnErrorFromAdd:    errors.New("bad request"),
+			returnErrorFromMark:   errors.New("bad request"),
+			expectedFailedCalls:   1,
+			expectedPublishedSize: 0}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			now := time.Now()
+			due := []models.Draft{{Model: gorm.Model{ID: 7}, UserName: "abc", Content: "hello"}}
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.
+				EXPECT().
+				ClaimDueDrafts(gomock.Any(), gomock.Any(), now, draftClaimLease, 10).
+				Return(&due, nil).
+				Times(1)
+			mockRepository.
+				EXPECT().
+				AddTweet(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, tweet *models.Tweet) error {
+					assert.Equal(t, uint(7), *tweet.DraftID)
+					return test.returnErrorFromAdd
+				}).
+				Times(1)
+			mockRepository.
+				EXPECT().
+				MarkDraftPublished(gomock.Any(), uint(7)).
+				Return(test.returnErrorFromMark).
+				Times(1)
+			mockRepository.
+				EXPECT().
+				MarkDraftFailed(gomock.Any(), uint(7), "bad request").
+				Return(nil).
+				Times(test.expectedFailedCalls)
+			ms := NewUserService(mockRepository, nil)
+
+			published, err := ms.PublishDueDrafts(context.Background(), now, 10)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedPublishedSize, published)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"example/layered-architecture/repositories"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Codes of the errors the service reports.
+const (
+	CodeNotFound     = "not_found"
+	CodeConflict     = "conflict"
+	CodeValidation   = "validation_failed"
+	CodeUnauthorized = "unauthorized"
+	CodeForbidden    = "forbidden"
+)
+
+// Error is a failure the caller can act on. Code tells what kind of failure it
+// is, Fields explains validation failures per input field. Any other error
+// coming out of the service is an internal one.
+type Error struct {
+	Code    string
+	Message string
+	Fields  map[string]string
+	err     error
+}
+
+// Error returns the message followed by the problems with each field.
+func (e *Error) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	fields := make([]string, 0, len(e.Fields))
+	for field, problem := range e.Fields {
+		fields = append(fields, field+" "+problem)
+	}
+	sort.Strings(fields)
+	return e.Message + ": " + strings.Join(fields, ", ")
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func NotFoundError(message string) *Error {
+	return &Error{Code: CodeNotFound, Message: message}
+}
+
+func ConflictError(message string) *Error {
+	return &Error{Code: CodeConflict, Message: message}
+}
+
+// ValidationError reports invalid input. fields maps input field names to
+// what is wrong with them and may be nil.
+func ValidationError(message string, fields map[string]string) *Error {
+	return &Error{Code: CodeValidation, Message: message, Fields: fields}
+}
+
+func UnauthorizedError(message string) *Error {
+	return &Error{Code: CodeUnauthorized, Message: message}
+}
+
+func ForbiddenError(message string) *Error {
+	return &Error{Code: CodeForbidden, Message: message}
+}
+
+// translate turns the errors of the repository into service errors. Errors it
+// does not know are passed on as they are.
+func translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return err
+	}
+	code := ""
+	switch {
+	case errors.Is(err, repositories.ErrNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		code = CodeNotFound
+	case errors.Is(err, repositories.ErrConflict):
+		code = CodeConflict
+	case errors.Is(err, repositories.ErrInvalid):
+		code = CodeValidation
+	case errors.Is(err, repositories.ErrUnauthorized):
+		code = CodeUnauthorized
+	default:
+		return err
+	}
+	return &Error{Code: code, Message: err.Error(), err: err}
+}
+
+// translated is translate for repository methods that also return a value.
+func translated[T any](value T, err error) (T, error) {
+	return value, translate(err)
+}
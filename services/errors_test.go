@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"example/layered-architecture/repositories"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslate(t *testing.T) {
+	type testCase struct {
+		name         string
+		err          error
+		expectedCode string
+	}
+	testCases := []testCase{{name: "not found",
+		err:          fmt.Errorf("user %q: %w", "abc", repositories.ErrNotFound),
+		expectedCode: CodeNotFound},
+		{name: "record not found",
+			err:          gorm.ErrRecordNotFound,
+			expectedCode: CodeNotFound},
+		{name: "conflict",
+			err:          fmt.Errorf("name %q: %w", "abc", repositories.ErrConflict),
+			expectedCode: CodeConflict},
+		{name: "invalid",
+			err:          fmt.Errorf("%w: users cannot follow themselves", repositories.ErrInvalid),
+			expectedCode: CodeValidation},
+		{name: "unauthorized",
+			err:          repositories.ErrUnauthorized,
+			expectedCode: CodeUnauthorized},
+		{name: "service error",
+			err:          ForbiddenError("not yours"),
+			expectedCode: CodeForbidden}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var serviceErr *Error
+			err := translate(test.err)
+
+			assert.True(t, errors.As(err, &serviceErr))
+			assert.Equal(t, test.expectedCode, serviceErr.Code)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestTranslateInternal(t *testing.T) {
+	err := errors.New("connection refused")
+	var serviceErr *Error
+
+	assert.Nil(t, translate(nil))
+	assert.False(t, errors.As(translate(err), &serviceErr))
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// maxMissedRounds is how many rounds a background worker may miss before
+	// it counts as stuck.
+	maxMissedRounds = 3
+	// minStaleAfter leaves workers with short intervals time for a big batch.
+	minStaleAfter = 5 * time.Minute
+)
+
+var errNotRunning = errors.New("not running")
+
+// Heartbeat tells whether a background worker is still doing its rounds.
+type Heartbeat struct {
+	running    atomic.Bool
+	staleAfter atomic.Int64
+	last       atomic.Int64
+}
+
+// start marks the worker as running with rounds every interval.
+func (h *Heartbeat) start(interval time.Duration) {
+	staleAfter := maxMissedRounds * interval
+	if staleAfter < minStaleAfter {
+		staleAfter = minStaleAfter
+	}
+	h.staleAfter.Store(int64(staleAfter))
+	h.beat()
+	h.running.Store(true)
+}
+
+// beat records that the worker finished a round, whether or not it failed.
+// Failures are logged, and the readiness of the database is checked apart.
+func (h *Heartbeat) beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+func (h *Heartbeat) stop() {
+	h.running.Store(false)
+}
+
+// Check returns an error unless the worker is running and finished a round
+// recently. It is meant for readiness probes.
+func (h *Heartbeat) Check(ctx context.Context) error {
+	if !h.running.Load() {
+		return errNotRunning
+	}
+	since := time.Since(time.Unix(0, h.last.Load()))
+	if since > time.Duration(h.staleAfter.Load()) {
+		return fmt.Errorf("no round finished for %s", since.Round(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeat(t *testing.T) {
+	var heartbeat Heartbeat
+	assert.ErrorIs(t, heartbeat.Check(context.Background()), errNotRunning)
+
+	heartbeat.start(time.Minute)
+	assert.NoError(t, heartbeat.Check(context.Background()))
+
+	//three missed rounds of a slow worker
+	heartbeat.start(time.Hour)
+	heartbeat.last.Store(time.Now().Add(-4 * time.Hour).UnixNano())
+	assert.ErrorContains(t, heartbeat.Check(context.Background()), "no round finished for 4h0m0s")
+
+	heartbeat.beat()
+	assert.NoError(t, heartbeat.Check(context.Background()))
+
+	heartbeat.stop()
+	assert.ErrorIs(t, heartbeat.Check(context.Background()), errNotRunning)
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+)
+
+const listTimelineSize = 50
+
+func (service *UserService) AddList(ctx context.Context, list *models.List) error {
+	err := validateList(list)
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.AddList(ctx, list))
+}
+
+// GetList returns the list if viewer is allowed to see it. Private lists
+// look exactly like missing ones to everybody but their owner.
+func (service *UserService) GetList(ctx context.Context, viewer string, listid int) (*models.List, error) {
+	list, err := service.repository.GetList(ctx, listid)
+	if err != nil {
+		return nil, translate(err)
+	}
+	if list.Private && list.OwnerName != viewer {
+		return nil, NotFoundError("list not found")
+	}
+	return list, nil
+}
+
+func (service *UserService) GetListsOfUser(ctx context.Context, username string) (*[]models.List, error) {
+	return translated(service.repository.GetListsOfUser(ctx, username))
+}
+
+func (service *UserService) DeleteList(ctx context.Context, username string, listid int) error {
+	_, err := service.ownedList(ctx, username, listid)
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.DeleteList(ctx, listid))
+}
+
+func (service *UserService) AddListMember(ctx context.Context, username string, member *models.ListMember) error {
+	_, err := service.ownedList(ctx, username, int(member.ListID))
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.AddListMember(ctx, member))
+}
+
+func (service *UserService) DeleteListMember(ctx context.Context, username string, listid int, membername string) error {
+	_, err := service.ownedList(ctx, username, listid)
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.DeleteListMember(ctx, listid, membername))
+}
+
+func (service *UserService) GetListMembers(ctx context.Context, viewer string, listid int) (*[]models.ListMember, error) {
+	_, err := service.GetList(ctx, viewer, listid)
+	if err != nil {
+		return nil, err
+	}
+	return translated(service.repository.GetListMembers(ctx, listid))
+}
+
+func (service *UserService) SubscribeList(ctx context.Context, username string, listid int) error {
+	list, err := service.GetList(ctx, username, listid)
+	if err != nil {
+		return err
+	}
+	//owners see their lists anyway
+	if list.OwnerName == username {
+		return ValidationError("cannot subscribe to own list", nil)
+	}
+	return translate(service.repository.SubscribeList(ctx, &models.ListSubscription{ListID: list.ID, UserName: username}))
+}
+
+func (service *UserService) UnsubscribeList(ctx context.Context, username string, listid int) error {
+	return translate(service.repository.UnsubscribeList(ctx, listid, username))
+}
+
+func (service *UserService) GetListTimeline(ctx context.Context, viewer string, listid int) (*[]models.Tweet, error) {
+	_, err := service.GetList(ctx, viewer, listid)
+	if err != nil {
+		return nil, err
+	}
+	return translated(service.repository.GetListTimeline(ctx, listid, listTimelineSize))
+}
+
+func (service *UserService) ownedList(ctx context.Context, username string, listid int) (*models.List, error) {
+	list, err := service.repository.GetList(ctx, listid)
+	if err != nil {
+		return nil, translate(err)
+	}
+	if list.OwnerName != username {
+		return nil, NotFoundError("list not found")
+	}
+	return list, nil
+}
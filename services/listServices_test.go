@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetListTimeline(t *testing.T) {
+	type testCase struct {
+		name           string
+		viewer         string
+		private        bool
+		expectedCalls  int
+		expectNotFound bool
+	}
+	testCases := []testCase{{name: "public list",
+		viewer:        "someone",
+		private:       false,
+		expectedCalls: 1},
+		{name: "private list of owner",
+			viewer:        "owner",
+			private:       true,
+			expectedCalls: 1},
+		{name: "private list of someone else",
+			viewer:         "someone",
+			private:        true,
+			expectedCalls:  0,
+			expectNotFound: true}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.
+				EXPECT().
+				GetList(gomock.Any(), 3).
+				Return(&models.List{Model: gorm.Model{ID: 3}, OwnerName: "owner", Private: test.private}, nil).
+				Times(1)
+			mockRepository.
+				EXPECT().
+				GetListTimeline(gomock.Any(), 3, listTimelineSize).
+				Return(&[]models.Tweet{}, nil).
+				Times(test.expectedCalls)
+			ms := NewUserService(mockRepository, nil)
+
+			_, err := ms.GetListTimeline(context.Background(), test.viewer, 3)
+
+			assert.Equal(t, test.expectNotFound, err != nil)
+		})
+	}
+}
+
+func TestAddListMember(t *testing.T) {
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		GetList(gomock.Any(), 3).
+		Return(&models.List{OwnerName: "owner"}, nil).
+		Times(2)
+	mockRepository.
+		EXPECT().
+		AddListMember(gomock.Any(), &models.ListMember{ListID: 3, UserName: "member"}).
+		Return(nil).
+		Times(1)
+	ms := NewUserService(mockRepository, nil)
+
+	err := ms.AddListMember(context.Background(), "someone", &models.ListMember{ListID: 3, UserName: "member"})
+	assert.NotNil(t, err)
+	err = ms.AddListMember(context.Background(), "owner", &models.ListMember{ListID: 3, UserName: "member"})
+	assert.Nil(t, err)
+}
+
+func TestSubscribeList(t *testing.T) {
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		GetList(gomock.Any(), 3).
+		Return(&models.List{Model: gorm.Model{ID: 3}, OwnerName: "owner"}, nil).
+		Times(2)
+	mockRepository.
+		EXPECT().
+		SubscribeList(gomock.Any(), &models.ListSubscription{ListID: 3, UserName: "fan"}).
+		Return(nil).
+		Times(1)
+	ms := NewUserService(mockRepository, nil)
+
+	assert.NotNil(t, ms.SubscribeList(context.Background(), "owner", 3))
+	assert.Nil(t, ms.SubscribeList(context.Background(), "fan", 3))
+}
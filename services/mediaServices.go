@@ -0,0 +1,154 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"example/layered-architecture/models"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+const (
+	// MaxMediaSize is the largest upload accepted, in bytes.
+	MaxMediaSize = 5 << 20
+	// maxMediaDimension guards against decompression bombs.
+	maxMediaDimension = 8192
+	thumbnailSize     = 320
+)
+
+func (service *UserService) UploadMedia(ctx context.Context, username string, data io.Reader) (*models.Media, error) {
+	//read one byte past the limit so oversized uploads can be detected
+	raw, err := io.ReadAll(io.LimitReader(data, MaxMediaSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 || len(raw) > MaxMediaSize {
+		return nil, ValidationError("media size not allowed", map[string]string{"file": "must be between 1 byte and 5 MB"})
+	}
+	//trust the bytes, not the client supplied content type
+	mimeType := http.DetectContentType(raw)
+	if mimeType != "image/jpeg" && mimeType != "image/png" {
+		return nil, ValidationError("unsupported media type", map[string]string{"file": "must be a JPEG or PNG image"})
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil || config.Width > maxMediaDimension || config.Height > maxMediaDimension {
+		return nil, ValidationError("invalid image", map[string]string{"file": "cannot be decoded or is too large"})
+	}
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, ValidationError("invalid image", map[string]string{"file": "cannot be decoded or is too large"})
+	}
+	//re-encoding drops EXIF and every other metadata segment of the original
+	var cleaned bytes.Buffer
+	if mimeType == "image/png" {
+		err = png.Encode(&cleaned, img)
+	} else {
+		err = jpeg.Encode(&cleaned, img, &jpeg.Options{Quality: 90})
+	}
+	if err != nil {
+		return nil, err
+	}
+	var thumbnail bytes.Buffer
+	err = jpeg.Encode(&thumbnail, resize(img, thumbnailSize), &jpeg.Options{Quality: 80})
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := randomKey()
+	if err != nil {
+		return nil, err
+	}
+	media := models.Media{
+		UserName:     username,
+		MimeType:     mimeType,
+		Size:         int64(cleaned.Len()),
+		Width:        config.Width,
+		Height:       config.Height,
+		BlobKey:      "media/" + id,
+		ThumbnailKey: "media/" + id + "_thumb",
+	}
+	err = service.blobs.Put(media.BlobKey, &cleaned)
+	if err != nil {
+		return nil, err
+	}
+	err = service.blobs.Put(media.ThumbnailKey, &thumbnail)
+	if err == nil {
+		err = service.repository.AddMedia(ctx, &media)
+	}
+	if err != nil {
+		service.blobs.Delete(media.BlobKey)
+		service.blobs.Delete(media.ThumbnailKey)
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (service *UserService) GetMedia(ctx context.Context, mediaid int, thumbnail bool) (*models.Media, io.ReadCloser, error) {
+	media, err := service.repository.GetMedia(ctx, mediaid)
+	if err != nil {
+		return nil, nil, translate(err)
+	}
+	key := media.BlobKey
+	if thumbnail {
+		key = media.ThumbnailKey
+	}
+	blob, err := service.blobs.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return media, blob, nil
+}
+
+// resize scales img so that its longest side is at most max pixels, averaging
+// the source pixels that fall into each destination pixel.
+func resize(img image.Image, max int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= max && height <= max {
+		return img
+	}
+	newWidth, newHeight := max, height*max/width
+	if height > width {
+		newWidth, newHeight = width*max/height, max
+	}
+	if newWidth < 1 {
+		newWidth = 1
+	}
+	if newHeight < 1 {
+		newHeight = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		y0, y1 := y*height/newHeight, (y+1)*height/newHeight
+		for x := 0; x < newWidth; x++ {
+			x0, x1 := x*width/newWidth, (x+1)*width/newWidth
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / n >> 8)
+			dst.Pix[offset+1] = uint8(g / n >> 8)
+			dst.Pix[offset+2] = uint8(b / n >> 8)
+			dst.Pix[offset+3] = uint8(a / n >> 8)
+		}
+	}
+	return dst
+}
+
+func randomKey() (string, error) {
+	buf := make([]byte, 16)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
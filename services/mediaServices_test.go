@@ -0,0 +1,107 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"example/layered-architecture/storage"
+	"image"
+	"image/jpeg"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadMedia(t *testing.T) {
+	var picture bytes.Buffer
+	jpeg.Encode(&picture, image.NewRGBA(image.Rect(0, 0, 800, 400)), nil)
+
+	type testCase struct {
+		name          string
+		data          []byte
+		expectedCalls int
+		expectError   bool
+	}
+	testCases := []testCase{{name: "not an image",
+		data:          []byte("just some text"),
+		expectedCalls: 0,
+		expectError:   true},
+		{name: "empty",
+			data:          nil,
+			expectedCalls: 0,
+			expectError:   true},
+		{name: "success",
+			data:          picture.Bytes(),
+			expectedCalls: 1,
+			expectError:   false}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			blobs, _ := storage.NewLocalBlobStore(t.TempDir())
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.
+				EXPECT().
+				AddMedia(gomock.Any(), gomock.Any()).
+				Return(nil).
+				Times(test.expectedCalls)
+			ms := NewUserService(mockRepository, blobs)
+			media, err := ms.UploadMedia(context.Background(), "abc", bytes.NewReader(test.data))
+			assert.Equal(t, test.expectError, err != nil)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, "image/jpeg", media.MimeType)
+			assert.Equal(t, 800, media.Width)
+
+			thumbnail, err := blobs.Get(media.ThumbnailKey)
+			assert.Nil(t, err)
+			defer thumbnail.Close()
+			config, _, err := image.DecodeConfig(thumbnail)
+			assert.Nil(t, err)
+			assert.Equal(t, 320, config.Width)
+			assert.Equal(t, 160, config.Height)
+		})
+	}
+}
+
+func TestUploadMediaStripsExif(t *testing.T) {
+	var picture bytes.Buffer
+	jpeg.Encode(&picture, image.NewRGBA(image.Rect(0, 0, 10, 10)), nil)
+	//splice an APP1 (EXIF) segment in right after the SOI marker
+	exif := append([]byte{0xFF, 0xE1, 0x00, 0x0C}, []byte("Exif\x00\x00GPS!")...)
+	withExif := append(append(append([]byte{}, picture.Bytes()[:2]...), exif...), picture.Bytes()[2:]...)
+
+	blobs, _ := storage.NewLocalBlobStore(t.TempDir())
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.EXPECT().AddMedia(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	ms := NewUserService(mockRepository, blobs)
+
+	media, err := ms.UploadMedia(context.Background(), "abc", bytes.NewReader(withExif))
+	assert.Nil(t, err)
+	blob, _ := blobs.Get(media.BlobKey)
+	stored, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.False(t, bytes.Contains(stored, []byte("Exif")))
+}
+
+func TestGetMedia(t *testing.T) {
+	blobs, _ := storage.NewLocalBlobStore(t.TempDir())
+	blobs.Put("media/a", bytes.NewReader([]byte("full")))
+	blobs.Put("media/a_thumb", bytes.NewReader([]byte("small")))
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		GetMedia(gomock.Any(), 1).
+		Return(&models.Media{BlobKey: "media/a", ThumbnailKey: "media/a_thumb"}, nil).
+		Times(1)
+	ms := NewUserService(mockRepository, blobs)
+
+	_, blob, err := ms.GetMedia(context.Background(), 1, true)
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, "small", string(data))
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Domain counters, exported along with the HTTP and database metrics.
+var (
+	tweetsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tweets_created_total",
+		Help: "Tweets created, including published drafts.",
+	})
+	followsAdded = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "follows_added_total",
+		Help: "Follows added.",
+	})
+	signInFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "signin_failures_total",
+		Help: "Sign ins rejected for a wrong name or password or a disabled account.",
+	})
+)
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainCounters(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockRepository := repositories.NewMockRepositoryInterface(mockCtrl)
+	expectTransactions(mockRepository)
+	service := NewUserService(mockRepository, nil)
+	tweets := testutil.ToFloat64(tweetsCreated)
+	follows := testutil.ToFloat64(followsAdded)
+	failures := testutil.ToFloat64(signInFailures)
+
+	mockRepository.EXPECT().AddTweet(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepository.EXPECT().AddTweet(gomock.Any(), gomock.Any()).Return(repositories.ErrNotFound)
+	mockRepository.EXPECT().AddFollowee(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepository.EXPECT().MarkSuggestionsStale(gomock.Any(), "abc").Return(nil)
+	mockRepository.EXPECT().SignIn(gomock.Any(), gomock.Any()).Return(repositories.ErrUnauthorized)
+	mockRepository.EXPECT().SignIn(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepository.EXPECT().ReactivateUser(gomock.Any(), "abc").Return(nil)
+
+	service.AddTweet(context.Background(), &models.Tweet{UserName: "abc", Content: "hello"})
+	service.AddTweet(context.Background(), &models.Tweet{UserName: "xyz", Content: "hello"})
+	service.AddFollowee(context.Background(), &models.Follows{SourceUser: "abc", TargetUser: "def"})
+	service.SignIn(context.Background(), &models.User{Name: "abc", Password: "wrong"})
+	service.SignIn(context.Background(), &models.User{Name: "abc", Password: "right"})
+
+	//failed attempts are not counted as created
+	assert.Equal(t, tweets+1, testutil.ToFloat64(tweetsCreated))
+	assert.Equal(t, follows+1, testutil.ToFloat64(followsAdded))
+	assert.Equal(t, failures+1, testutil.ToFloat64(signInFailures))
+}
@@ -5,7 +5,9 @@
 package services
 
 import (
+	context "context"
 	models "example/layered-architecture/models"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,145 +36,756 @@ func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AddBookmark mocks base method.
+func (m *MockServiceInterface) AddBookmark(arg0 context.Context, arg1 *models.Bookmark) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookmark", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBookmark indicates an expected call of AddBookmark.
+func (mr *MockServiceInterfaceMockRecorder) AddBookmark(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookmark", reflect.TypeOf((*MockServiceInterface)(nil).AddBookmark), arg0, arg1)
+}
+
+// AddBookmarkFolder mocks base method.
+func (m *MockServiceInterface) AddBookmarkFolder(arg0 context.Context, arg1 *models.BookmarkFolder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookmarkFolder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBookmarkFolder indicates an expected call of AddBookmarkFolder.
+func (mr *MockServiceInterfaceMockRecorder) AddBookmarkFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookmarkFolder", reflect.TypeOf((*MockServiceInterface)(nil).AddBookmarkFolder), arg0, arg1)
+}
+
+// AddDraft mocks base method.
+func (m *MockServiceInterface) AddDraft(arg0 context.Context, arg1 *models.Draft) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDraft", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDraft indicates an expected call of AddDraft.
+func (mr *MockServiceInterfaceMockRecorder) AddDraft(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDraft", reflect.TypeOf((*MockServiceInterface)(nil).AddDraft), arg0, arg1)
+}
+
 // AddFollowee mocks base method.
-func (m *MockServiceInterface) AddFollowee(arg0 *models.Follows) error {
+func (m *MockServiceInterface) AddFollowee(arg0 context.Context, arg1 *models.Follows) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddFollowee", arg0)
+	ret := m.ctrl.Call(m, "AddFollowee", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddFollowee indicates an expected call of AddFollowee.
-func (mr *MockServiceInterfaceMockRecorder) AddFollowee(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) AddFollowee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFollowee", reflect.TypeOf((*MockServiceInterface)(nil).AddFollowee), arg0, arg1)
+}
+
+// AddList mocks base method.
+func (m *MockServiceInterface) AddList(arg0 context.Context, arg1 *models.List) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddList indicates an expected call of AddList.
+func (mr *MockServiceInterfaceMockRecorder) AddList(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFollowee", reflect.TypeOf((*MockServiceInterface)(nil).AddFollowee), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddList", reflect.TypeOf((*MockServiceInterface)(nil).AddList), arg0, arg1)
+}
+
+// AddListMember mocks base method.
+func (m *MockServiceInterface) AddListMember(arg0 context.Context, arg1 string, arg2 *models.ListMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddListMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddListMember indicates an expected call of AddListMember.
+func (mr *MockServiceInterfaceMockRecorder) AddListMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddListMember", reflect.TypeOf((*MockServiceInterface)(nil).AddListMember), arg0, arg1, arg2)
 }
 
 // AddTweet mocks base method.
-func (m *MockServiceInterface) AddTweet(arg0 *models.Tweet) error {
+func (m *MockServiceInterface) AddTweet(arg0 context.Context, arg1 *models.Tweet) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTweet", arg0)
+	ret := m.ctrl.Call(m, "AddTweet", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddTweet indicates an expected call of AddTweet.
-func (mr *MockServiceInterfaceMockRecorder) AddTweet(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) AddTweet(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTweet", reflect.TypeOf((*MockServiceInterface)(nil).AddTweet), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTweet", reflect.TypeOf((*MockServiceInterface)(nil).AddTweet), arg0, arg1)
 }
 
 // AddUser mocks base method.
-func (m *MockServiceInterface) AddUser(arg0 *models.User) error {
+func (m *MockServiceInterface) AddUser(arg0 context.Context, arg1 *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUser", arg0)
+	ret := m.ctrl.Call(m, "AddUser", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddUser indicates an expected call of AddUser.
-func (mr *MockServiceInterfaceMockRecorder) AddUser(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) AddUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockServiceInterface)(nil).AddUser), arg0, arg1)
+}
+
+// DeactivateUser mocks base method.
+func (m *MockServiceInterface) DeactivateUser(arg0 context.Context, arg1 *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockServiceInterfaceMockRecorder) DeactivateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockServiceInterface)(nil).DeactivateUser), arg0, arg1)
+}
+
+// DeleteBookmark mocks base method.
+func (m *MockServiceInterface) DeleteBookmark(arg0 context.Context, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBookmark", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBookmark indicates an expected call of DeleteBookmark.
+func (mr *MockServiceInterfaceMockRecorder) DeleteBookmark(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBookmark", reflect.TypeOf((*MockServiceInterface)(nil).DeleteBookmark), arg0, arg1, arg2)
+}
+
+// DeleteBookmarkFolder mocks base method.
+func (m *MockServiceInterface) DeleteBookmarkFolder(arg0 context.Context, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBookmarkFolder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBookmarkFolder indicates an expected call of DeleteBookmarkFolder.
+func (mr *MockServiceInterfaceMockRecorder) DeleteBookmarkFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockServiceInterface)(nil).AddUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBookmarkFolder", reflect.TypeOf((*MockServiceInterface)(nil).DeleteBookmarkFolder), arg0, arg1, arg2)
 }
 
-// CheckFollowing mocks base method.
-func (m *MockServiceInterface) CheckFollowing(arg0, arg1 string) error {
+// DeleteDraft mocks base method.
+func (m *MockServiceInterface) DeleteDraft(arg0 context.Context, arg1 string, arg2 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckFollowing", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteDraft", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CheckFollowing indicates an expected call of CheckFollowing.
-func (mr *MockServiceInterfaceMockRecorder) CheckFollowing(arg0, arg1 interface{}) *gomock.Call {
+// DeleteDraft indicates an expected call of DeleteDraft.
+func (mr *MockServiceInterfaceMockRecorder) DeleteDraft(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckFollowing", reflect.TypeOf((*MockServiceInterface)(nil).CheckFollowing), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDraft", reflect.TypeOf((*MockServiceInterface)(nil).DeleteDraft), arg0, arg1, arg2)
 }
 
 // DeleteFollowee mocks base method.
-func (m *MockServiceInterface) DeleteFollowee(arg0, arg1 string) error {
+func (m *MockServiceInterface) DeleteFollowee(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFollowee", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteFollowee", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFollowee indicates an expected call of DeleteFollowee.
-func (mr *MockServiceInterfaceMockRecorder) DeleteFollowee(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) DeleteFollowee(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFollowee", reflect.TypeOf((*MockServiceInterface)(nil).DeleteFollowee), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFollowee", reflect.TypeOf((*MockServiceInterface)(nil).DeleteFollowee), arg0, arg1, arg2)
+}
+
+// DeleteList mocks base method.
+func (m *MockServiceInterface) DeleteList(arg0 context.Context, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockServiceInterfaceMockRecorder) DeleteList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockServiceInterface)(nil).DeleteList), arg0, arg1, arg2)
+}
+
+// DeleteListMember mocks base method.
+func (m *MockServiceInterface) DeleteListMember(arg0 context.Context, arg1 string, arg2 int, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteListMember", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteListMember indicates an expected call of DeleteListMember.
+func (mr *MockServiceInterfaceMockRecorder) DeleteListMember(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListMember", reflect.TypeOf((*MockServiceInterface)(nil).DeleteListMember), arg0, arg1, arg2, arg3)
 }
 
 // DeleteTweet mocks base method.
-func (m *MockServiceInterface) DeleteTweet(arg0 int) error {
+func (m *MockServiceInterface) DeleteTweet(arg0 context.Context, arg1 int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTweet", arg0)
+	ret := m.ctrl.Call(m, "DeleteTweet", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTweet indicates an expected call of DeleteTweet.
-func (mr *MockServiceInterfaceMockRecorder) DeleteTweet(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) DeleteTweet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTweet", reflect.TypeOf((*MockServiceInterface)(nil).DeleteTweet), arg0, arg1)
+}
+
+// DisableUser mocks base method.
+func (m *MockServiceInterface) DisableUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockServiceInterfaceMockRecorder) DisableUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTweet", reflect.TypeOf((*MockServiceInterface)(nil).DeleteTweet), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockServiceInterface)(nil).DisableUser), arg0, arg1)
+}
+
+// EnableUser mocks base method.
+func (m *MockServiceInterface) EnableUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableUser indicates an expected call of EnableUser.
+func (mr *MockServiceInterfaceMockRecorder) EnableUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUser", reflect.TypeOf((*MockServiceInterface)(nil).EnableUser), arg0, arg1)
+}
+
+// Export mocks base method.
+func (m *MockServiceInterface) Export(arg0 context.Context, arg1, arg2 string, arg3 bool, arg4 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockServiceInterfaceMockRecorder) Export(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockServiceInterface)(nil).Export), arg0, arg1, arg2, arg3, arg4)
 }
 
 // GetAllUsers mocks base method.
-func (m *MockServiceInterface) GetAllUsers() (*[]models.User, error) {
+func (m *MockServiceInterface) GetAllUsers(arg0 context.Context) (*[]models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUsers")
+	ret := m.ctrl.Call(m, "GetAllUsers", arg0)
 	ret0, _ := ret[0].(*[]models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllUsers indicates an expected call of GetAllUsers.
-func (mr *MockServiceInterfaceMockRecorder) GetAllUsers() *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) GetAllUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockServiceInterface)(nil).GetAllUsers), arg0)
+}
+
+// GetBookmarkFolders mocks base method.
+func (m *MockServiceInterface) GetBookmarkFolders(arg0 context.Context, arg1 string) (*[]models.BookmarkFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookmarkFolders", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.BookmarkFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookmarkFolders indicates an expected call of GetBookmarkFolders.
+func (mr *MockServiceInterfaceMockRecorder) GetBookmarkFolders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookmarkFolders", reflect.TypeOf((*MockServiceInterface)(nil).GetBookmarkFolders), arg0, arg1)
+}
+
+// GetBookmarks mocks base method.
+func (m *MockServiceInterface) GetBookmarks(arg0 context.Context, arg1 string, arg2 *uint, arg3 string, arg4 int) (*models.BookmarkPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookmarks", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*models.BookmarkPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookmarks indicates an expected call of GetBookmarks.
+func (mr *MockServiceInterfaceMockRecorder) GetBookmarks(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookmarks", reflect.TypeOf((*MockServiceInterface)(nil).GetBookmarks), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetDataExport mocks base method.
+func (m *MockServiceInterface) GetDataExport(arg0 context.Context, arg1 string) (*models.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataExport", arg0, arg1)
+	ret0, _ := ret[0].(*models.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataExport indicates an expected call of GetDataExport.
+func (mr *MockServiceInterfaceMockRecorder) GetDataExport(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockServiceInterface)(nil).GetAllUsers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataExport", reflect.TypeOf((*MockServiceInterface)(nil).GetDataExport), arg0, arg1)
+}
+
+// GetDraft mocks base method.
+func (m *MockServiceInterface) GetDraft(arg0 context.Context, arg1 string, arg2 int) (*models.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockServiceInterfaceMockRecorder) GetDraft(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockServiceInterface)(nil).GetDraft), arg0, arg1, arg2)
+}
+
+// GetDraftsOfUser mocks base method.
+func (m *MockServiceInterface) GetDraftsOfUser(arg0 context.Context, arg1 string) (*[]models.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraftsOfUser", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraftsOfUser indicates an expected call of GetDraftsOfUser.
+func (mr *MockServiceInterfaceMockRecorder) GetDraftsOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraftsOfUser", reflect.TypeOf((*MockServiceInterface)(nil).GetDraftsOfUser), arg0, arg1)
 }
 
 // GetFolloweesOfUser mocks base method.
-func (m *MockServiceInterface) GetFolloweesOfUser(arg0 string) (*[]models.Follows, error) {
+func (m *MockServiceInterface) GetFolloweesOfUser(arg0 context.Context, arg1 string) (*[]models.Follows, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFolloweesOfUser", arg0)
+	ret := m.ctrl.Call(m, "GetFolloweesOfUser", arg0, arg1)
 	ret0, _ := ret[0].(*[]models.Follows)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFolloweesOfUser indicates an expected call of GetFolloweesOfUser.
-func (mr *MockServiceInterfaceMockRecorder) GetFolloweesOfUser(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) GetFolloweesOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolloweesOfUser", reflect.TypeOf((*MockServiceInterface)(nil).GetFolloweesOfUser), arg0, arg1)
+}
+
+// GetList mocks base method.
+func (m *MockServiceInterface) GetList(arg0 context.Context, arg1 string, arg2 int) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockServiceInterfaceMockRecorder) GetList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockServiceInterface)(nil).GetList), arg0, arg1, arg2)
+}
+
+// GetListMembers mocks base method.
+func (m *MockServiceInterface) GetListMembers(arg0 context.Context, arg1 string, arg2 int) (*[]models.ListMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMembers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]models.ListMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMembers indicates an expected call of GetListMembers.
+func (mr *MockServiceInterfaceMockRecorder) GetListMembers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMembers", reflect.TypeOf((*MockServiceInterface)(nil).GetListMembers), arg0, arg1, arg2)
+}
+
+// GetListTimeline mocks base method.
+func (m *MockServiceInterface) GetListTimeline(arg0 context.Context, arg1 string, arg2 int) (*[]models.Tweet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListTimeline", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*[]models.Tweet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListTimeline indicates an expected call of GetListTimeline.
+func (mr *MockServiceInterfaceMockRecorder) GetListTimeline(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListTimeline", reflect.TypeOf((*MockServiceInterface)(nil).GetListTimeline), arg0, arg1, arg2)
+}
+
+// GetListsOfUser mocks base method.
+func (m *MockServiceInterface) GetListsOfUser(arg0 context.Context, arg1 string) (*[]models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListsOfUser", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListsOfUser indicates an expected call of GetListsOfUser.
+func (mr *MockServiceInterfaceMockRecorder) GetListsOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListsOfUser", reflect.TypeOf((*MockServiceInterface)(nil).GetListsOfUser), arg0, arg1)
+}
+
+// GetMedia mocks base method.
+func (m *MockServiceInterface) GetMedia(arg0 context.Context, arg1 int, arg2 bool) (*models.Media, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedia", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMedia indicates an expected call of GetMedia.
+func (mr *MockServiceInterfaceMockRecorder) GetMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedia", reflect.TypeOf((*MockServiceInterface)(nil).GetMedia), arg0, arg1, arg2)
+}
+
+// GetPoll mocks base method.
+func (m *MockServiceInterface) GetPoll(arg0 context.Context, arg1 string, arg2 int) (*models.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoll", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPoll indicates an expected call of GetPoll.
+func (mr *MockServiceInterfaceMockRecorder) GetPoll(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockServiceInterface)(nil).GetPoll), arg0, arg1, arg2)
+}
+
+// GetRelationship mocks base method.
+func (m *MockServiceInterface) GetRelationship(arg0 context.Context, arg1, arg2 string) (*models.Relationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelationship", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Relationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelationship indicates an expected call of GetRelationship.
+func (mr *MockServiceInterfaceMockRecorder) GetRelationship(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelationship", reflect.TypeOf((*MockServiceInterface)(nil).GetRelationship), arg0, arg1, arg2)
+}
+
+// GetRenamedUsername mocks base method.
+func (m *MockServiceInterface) GetRenamedUsername(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRenamedUsername", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRenamedUsername indicates an expected call of GetRenamedUsername.
+func (mr *MockServiceInterfaceMockRecorder) GetRenamedUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRenamedUsername", reflect.TypeOf((*MockServiceInterface)(nil).GetRenamedUsername), arg0, arg1)
+}
+
+// GetStats mocks base method.
+func (m *MockServiceInterface) GetStats(arg0 context.Context) (*models.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0)
+	ret0, _ := ret[0].(*models.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockServiceInterfaceMockRecorder) GetStats(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolloweesOfUser", reflect.TypeOf((*MockServiceInterface)(nil).GetFolloweesOfUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockServiceInterface)(nil).GetStats), arg0)
+}
+
+// GetSuggestions mocks base method.
+func (m *MockServiceInterface) GetSuggestions(arg0 context.Context, arg1 string) (*[]models.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuggestions", arg0, arg1)
+	ret0, _ := ret[0].(*[]models.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuggestions indicates an expected call of GetSuggestions.
+func (mr *MockServiceInterfaceMockRecorder) GetSuggestions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuggestions", reflect.TypeOf((*MockServiceInterface)(nil).GetSuggestions), arg0, arg1)
 }
 
 // GetTweetsOfUser mocks base method.
-func (m *MockServiceInterface) GetTweetsOfUser(arg0 string) (*[]models.Tweet, error) {
+func (m *MockServiceInterface) GetTweetsOfUser(arg0 context.Context, arg1 string) (*[]models.Tweet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTweetsOfUser", arg0)
+	ret := m.ctrl.Call(m, "GetTweetsOfUser", arg0, arg1)
 	ret0, _ := ret[0].(*[]models.Tweet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTweetsOfUser indicates an expected call of GetTweetsOfUser.
-func (mr *MockServiceInterfaceMockRecorder) GetTweetsOfUser(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) GetTweetsOfUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTweetsOfUser", reflect.TypeOf((*MockServiceInterface)(nil).GetTweetsOfUser), arg0, arg1)
+}
+
+// Import mocks base method.
+func (m *MockServiceInterface) Import(arg0 context.Context, arg1, arg2 string, arg3 io.Reader) (*models.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockServiceInterfaceMockRecorder) Import(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockServiceInterface)(nil).Import), arg0, arg1, arg2, arg3)
+}
+
+// ListUsers mocks base method.
+func (m *MockServiceInterface) ListUsers(arg0 context.Context) (*[]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0)
+	ret0, _ := ret[0].(*[]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockServiceInterfaceMockRecorder) ListUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockServiceInterface)(nil).ListUsers), arg0)
+}
+
+// OpenDataExport mocks base method.
+func (m *MockServiceInterface) OpenDataExport(arg0 context.Context, arg1 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenDataExport", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenDataExport indicates an expected call of OpenDataExport.
+func (mr *MockServiceInterfaceMockRecorder) OpenDataExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDataExport", reflect.TypeOf((*MockServiceInterface)(nil).OpenDataExport), arg0, arg1)
+}
+
+// PinTweet mocks base method.
+func (m *MockServiceInterface) PinTweet(arg0 context.Context, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinTweet", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinTweet indicates an expected call of PinTweet.
+func (mr *MockServiceInterfaceMockRecorder) PinTweet(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinTweet", reflect.TypeOf((*MockServiceInterface)(nil).PinTweet), arg0, arg1, arg2)
+}
+
+// RenameUser mocks base method.
+func (m *MockServiceInterface) RenameUser(arg0 context.Context, arg1 *models.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameUser indicates an expected call of RenameUser.
+func (mr *MockServiceInterfaceMockRecorder) RenameUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameUser", reflect.TypeOf((*MockServiceInterface)(nil).RenameUser), arg0, arg1, arg2)
+}
+
+// RepairFollows mocks base method.
+func (m *MockServiceInterface) RepairFollows(arg0 context.Context, arg1 bool) (*models.FollowRepair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairFollows", arg0, arg1)
+	ret0, _ := ret[0].(*models.FollowRepair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairFollows indicates an expected call of RepairFollows.
+func (mr *MockServiceInterfaceMockRecorder) RepairFollows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairFollows", reflect.TypeOf((*MockServiceInterface)(nil).RepairFollows), arg0, arg1)
+}
+
+// RequestDataExport mocks base method.
+func (m *MockServiceInterface) RequestDataExport(arg0 context.Context, arg1 string) (*models.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDataExport", arg0, arg1)
+	ret0, _ := ret[0].(*models.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestDataExport indicates an expected call of RequestDataExport.
+func (mr *MockServiceInterfaceMockRecorder) RequestDataExport(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTweetsOfUser", reflect.TypeOf((*MockServiceInterface)(nil).GetTweetsOfUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDataExport", reflect.TypeOf((*MockServiceInterface)(nil).RequestDataExport), arg0, arg1)
+}
+
+// ResetPassword mocks base method.
+func (m *MockServiceInterface) ResetPassword(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockServiceInterfaceMockRecorder) ResetPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockServiceInterface)(nil).ResetPassword), arg0, arg1, arg2)
 }
 
 // SignIn mocks base method.
-func (m *MockServiceInterface) SignIn(arg0 *models.User) error {
+func (m *MockServiceInterface) SignIn(arg0 context.Context, arg1 *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SignIn", arg0)
+	ret := m.ctrl.Call(m, "SignIn", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SignIn indicates an expected call of SignIn.
-func (mr *MockServiceInterfaceMockRecorder) SignIn(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) SignIn(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockServiceInterface)(nil).SignIn), arg0, arg1)
+}
+
+// SubscribeList mocks base method.
+func (m *MockServiceInterface) SubscribeList(arg0 context.Context, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeList indicates an expected call of SubscribeList.
+func (mr *MockServiceInterfaceMockRecorder) SubscribeList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeList", reflect.TypeOf((*MockServiceInterface)(nil).SubscribeList), arg0, arg1, arg2)
+}
+
+// UnpinTweet mocks base method.
+func (m *MockServiceInterface) UnpinTweet(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinTweet", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpinTweet indicates an expected call of UnpinTweet.
+func (mr *MockServiceInterfaceMockRecorder) UnpinTweet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinTweet", reflect.TypeOf((*MockServiceInterface)(nil).UnpinTweet), arg0, arg1)
+}
+
+// UnsubscribeList mocks base method.
+func (m *MockServiceInterface) UnsubscribeList(arg0 context.Context, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsubscribeList indicates an expected call of UnsubscribeList.
+func (mr *MockServiceInterfaceMockRecorder) UnsubscribeList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeList", reflect.TypeOf((*MockServiceInterface)(nil).UnsubscribeList), arg0, arg1, arg2)
+}
+
+// UpdateDraft mocks base method.
+func (m *MockServiceInterface) UpdateDraft(arg0 context.Context, arg1 string, arg2 *models.Draft) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDraft", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDraft indicates an expected call of UpdateDraft.
+func (mr *MockServiceInterfaceMockRecorder) UpdateDraft(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDraft", reflect.TypeOf((*MockServiceInterface)(nil).UpdateDraft), arg0, arg1, arg2)
+}
+
+// UploadMedia mocks base method.
+func (m *MockServiceInterface) UploadMedia(arg0 context.Context, arg1 string, arg2 io.Reader) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadMedia", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadMedia indicates an expected call of UploadMedia.
+func (mr *MockServiceInterfaceMockRecorder) UploadMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadMedia", reflect.TypeOf((*MockServiceInterface)(nil).UploadMedia), arg0, arg1, arg2)
+}
+
+// VotePoll mocks base method.
+func (m *MockServiceInterface) VotePoll(arg0 context.Context, arg1 string, arg2 int, arg3 uint) (*models.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VotePoll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VotePoll indicates an expected call of VotePoll.
+func (mr *MockServiceInterfaceMockRecorder) VotePoll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockServiceInterface)(nil).SignIn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VotePoll", reflect.TypeOf((*MockServiceInterface)(nil).VotePoll), arg0, arg1, arg2, arg3)
 }
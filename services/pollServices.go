@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	minPollOptions      = 2
+	maxPollOptions      = 4
+	maxPollOptionLength = 25
+	minPollDuration     = 5 * time.Minute
+	maxPollDuration     = 7 * 24 * time.Hour
+)
+
+// preparePoll validates a poll sent along with a new tweet and works out when
+// it closes. Ids sent by the client are dropped: the poll and its options are
+// saved together with the tweet, and rows with an id would move existing
+// polls, votes and all, onto it.
+func preparePoll(poll *models.Poll, now time.Time) error {
+	poll.Model = gorm.Model{}
+	poll.TweetID = 0
+	for i := range poll.Options {
+		poll.Options[i].ID = 0
+		poll.Options[i].PollID = 0
+	}
+	v := validation{}
+	if len(poll.Options) < minPollOptions || len(poll.Options) > maxPollOptions {
+		v.fail("poll.options", fmt.Sprintf("needs between %d and %d options", minPollOptions, maxPollOptions))
+	}
+	for i, option := range poll.Options {
+		v.check(fmt.Sprintf("poll.options.%d.text", i), option.Text, required, length(1, maxPollOptionLength))
+	}
+	duration := time.Duration(poll.DurationMinutes) * time.Minute
+	if duration < minPollDuration || duration > maxPollDuration {
+		v.fail("poll.durationminutes", "must be between 5 minutes and 7 days")
+	}
+	err := v.err("invalid poll")
+	if err != nil {
+		return err
+	}
+	poll.ClosesAt = now.Add(duration)
+	return nil
+}
+
+// GetPoll returns the poll of a tweet. Results are only included when the
+// viewer has voted or the poll is closed.
+func (service *UserService) GetPoll(ctx context.Context, viewer string, tweetid int) (*models.Poll, error) {
+	poll, err := service.repository.GetPoll(ctx, tweetid)
+	if err != nil {
+		return nil, translate(err)
+	}
+	poll.Closed = !time.Now().Before(poll.ClosesAt)
+	if viewer != "" {
+		vote, err := service.repository.GetPollVote(ctx, poll.ID, viewer)
+		if err != nil {
+			return nil, translate(err)
+		}
+		if vote != nil {
+			poll.VotedOption = &vote.OptionID
+		}
+	}
+	if !poll.Closed && poll.VotedOption == nil {
+		return poll, nil
+	}
+	counts, err := service.repository.CountPollVotes(ctx, poll.ID)
+	if err != nil {
+		return nil, translate(err)
+	}
+	total := 0
+	for i := range poll.Options {
+		votes := counts[poll.Options[i].ID]
+		poll.Options[i].Votes = &votes
+		total += votes
+	}
+	poll.TotalVotes = &total
+	return poll, nil
+}
+
+// VotePoll records the user's vote and returns the poll with its results.
+func (service *UserService) VotePoll(ctx context.Context, username string, tweetid int, optionid uint) (*models.Poll, error) {
+	poll, err := service.repository.GetPoll(ctx, tweetid)
+	if err != nil {
+		return nil, translate(err)
+	}
+	vote := models.PollVote{PollID: poll.ID, OptionID: optionid, UserName: username}
+	err = service.repository.AddPollVote(ctx, &vote, time.Now())
+	if err != nil {
+		return nil, translate(err)
+	}
+	return service.GetPoll(ctx, username, tweetid)
+}
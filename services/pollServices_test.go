@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAddTweetWithPoll(t *testing.T) {
+	type testCase struct {
+		name          string
+		poll          *models.Poll
+		expectedCalls int
+	}
+	testCases := []testCase{{name: "one option",
+		poll:          &models.Poll{Options: []models.PollOption{{Text: "a"}}, DurationMinutes: 60},
+		expectedCalls: 0},
+		{name: "too short",
+			poll:          &models.Poll{Options: []models.PollOption{{Text: "a"}, {Text: "b"}}, DurationMinutes: 1},
+			expectedCalls: 0},
+		{name: "success",
+			poll:          &models.Poll{Options: []models.PollOption{{Text: "a"}, {Text: "b"}}, DurationMinutes: 60},
+			expectedCalls: 1}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.
+				EXPECT().
+				AddTweet(gomock.Any(), gomock.Any()).
+				Return(nil).
+				Times(test.expectedCalls)
+			ms := NewUserService(mockRepository, nil)
+
+			err := ms.AddTweet(context.Background(), &models.Tweet{UserName: "abc", Content: "vote!", Poll: test.poll})
+
+			assert.Equal(t, test.expectedCalls == 0, err != nil)
+			if err == nil {
+				assert.WithinDuration(t, time.Now().Add(time.Hour), test.poll.ClosesAt, time.Minute)
+			}
+		})
+	}
+}
+
+func TestAddTweetWithPollDropsIDs(t *testing.T) {
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		AddTweet(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tweet *models.Tweet) error {
+			//with ids gorm would update the rows of another tweet's poll
+			assert.Zero(t, tweet.Poll.ID)
+			assert.Zero(t, tweet.Poll.TweetID)
+			for _, option := range tweet.Poll.Options {
+				assert.Zero(t, option.ID)
+				assert.Zero(t, option.PollID)
+			}
+			return nil
+		})
+	ms := NewUserService(mockRepository, nil)
+	poll := &models.Poll{Model: gorm.Model{ID: 4}, TweetID: 9, DurationMinutes: 60,
+		Options: []models.PollOption{{ID: 11, PollID: 4, Text: "a"}, {ID: 12, PollID: 4, Text: "b"}}}
+
+	err := ms.AddTweet(context.Background(), &models.Tweet{UserName: "abc", Content: "vote!", Poll: poll})
+
+	assert.NoError(t, err)
+}
+
+func TestGetPoll(t *testing.T) {
+	type testCase struct {
+		name          string
+		closesAt      time.Time
+		vote          *models.PollVote
+		expectResults bool
+	}
+	testCases := []testCase{{name: "open and not voted",
+		closesAt:      time.Now().Add(time.Hour),
+		vote:          nil,
+		expectResults: false},
+		{name: "open and voted",
+			closesAt:      time.Now().Add(time.Hour),
+			vote:          &models.PollVote{OptionID: 2},
+			expectResults: true},
+		{name: "closed",
+			closesAt:      time.Now().Add(-time.Hour),
+			vote:          nil,
+			expectResults: true}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			poll := models.Poll{Model: gorm.Model{ID: 5}, ClosesAt: test.closesAt,
+				Options: []models.PollOption{{ID: 1, Text: "a"}, {ID: 2, Text: "b"}}}
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.EXPECT().GetPoll(gomock.Any(), 9).Return(&poll, nil).Times(1)
+			mockRepository.EXPECT().GetPollVote(gomock.Any(), uint(5), "abc").Return(test.vote, nil).Times(1)
+			calls := 0
+			if test.expectResults {
+				calls = 1
+			}
+			mockRepository.
+				EXPECT().
+				CountPollVotes(gomock.Any(), uint(5)).
+				Return(map[uint]int{1: 3, 2: 4}, nil).
+				Times(calls)
+			ms := NewUserService(mockRepository, nil)
+
+			result, err := ms.GetPoll(context.Background(), "abc", 9)
+
+			assert.Nil(t, err)
+			if !test.expectResults {
+				assert.Nil(t, result.TotalVotes)
+				assert.Nil(t, result.Options[0].Votes)
+				return
+			}
+			assert.Equal(t, 7, *result.TotalVotes)
+			assert.Equal(t, 4, *result.Options[1].Votes)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	// draftClaimLease is how long a scheduler owns a claimed draft before
+	// another instance may pick it up again.
+	draftClaimLease = 5 * time.Minute
+	draftBatchSize  = 100
+)
+
+// Scheduler periodically publishes scheduled drafts. Pending drafts live in
+// the repository, so nothing is lost when the server restarts.
+type Scheduler struct {
+	service   *UserService
+	interval  time.Duration
+	heartbeat Heartbeat
+}
+
+func NewScheduler(service *UserService, interval time.Duration) *Scheduler {
+	return &Scheduler{service: service, interval: interval}
+}
+
+// Run publishes due drafts every interval until ctx is cancelled.
+func (scheduler *Scheduler) Run(ctx context.Context) {
+	scheduler.heartbeat.start(scheduler.interval)
+	defer scheduler.heartbeat.stop()
+	ticker := time.NewTicker(scheduler.interval)
+	defer ticker.Stop()
+	for {
+		roundCtx, span := startRound(ctx, "scheduler")
+		_, err := scheduler.service.PublishDueDrafts(roundCtx, time.Now(), draftBatchSize)
+		if err != nil {
+			slog.ErrorContext(roundCtx, "cannot publish scheduled tweets", "error", err)
+		}
+		end(span, err)
+		scheduler.heartbeat.beat()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Heartbeat tells whether the scheduler is still publishing scheduled drafts.
+func (scheduler *Scheduler) Heartbeat() *Heartbeat {
+	return &scheduler.heartbeat
+}
@@ -1,19 +1,63 @@
 package services
 
 import (
+	"context"
 	"example/layered-architecture/models"
+	"io"
 )
 
 //go:generate mockgen --destination=./mock_service_interface.go --package=services example/layered-architecture/services ServiceInterface
 type ServiceInterface interface {
-	AddUser(user *models.User) error
-	SignIn(user *models.User) error
-	GetAllUsers() (*[]models.User, error)
-	AddTweet(tweet *models.Tweet) error
-	GetTweetsOfUser(username string) (*[]models.Tweet, error)
-	GetFolloweesOfUser(username string) (*[]models.Follows, error)
-	AddFollowee(follow *models.Follows) error
-	DeleteTweet(tweetid int) error
-	DeleteFollowee(username string, followeename string) error
-	CheckFollowing(username string, followeename string) error
+	AddUser(ctx context.Context, user *models.User) error
+	SignIn(ctx context.Context, user *models.User) error
+	GetAllUsers(ctx context.Context) (*[]models.User, error)
+	AddTweet(ctx context.Context, tweet *models.Tweet) error
+	GetTweetsOfUser(ctx context.Context, username string) (*[]models.Tweet, error)
+	GetFolloweesOfUser(ctx context.Context, username string) (*[]models.Follows, error)
+	AddFollowee(ctx context.Context, follow *models.Follows) error
+	DeleteTweet(ctx context.Context, tweetid int) error
+	DeleteFollowee(ctx context.Context, username string, followeename string) error
+	GetRelationship(ctx context.Context, source string, target string) (*models.Relationship, error)
+	UploadMedia(ctx context.Context, username string, data io.Reader) (*models.Media, error)
+	GetMedia(ctx context.Context, mediaid int, thumbnail bool) (*models.Media, io.ReadCloser, error)
+	AddDraft(ctx context.Context, draft *models.Draft) error
+	GetDraftsOfUser(ctx context.Context, username string) (*[]models.Draft, error)
+	GetDraft(ctx context.Context, username string, draftid int) (*models.Draft, error)
+	UpdateDraft(ctx context.Context, username string, draft *models.Draft) error
+	DeleteDraft(ctx context.Context, username string, draftid int) error
+	PinTweet(ctx context.Context, username string, tweetid int) error
+	UnpinTweet(ctx context.Context, username string) error
+	AddBookmark(ctx context.Context, bookmark *models.Bookmark) error
+	DeleteBookmark(ctx context.Context, username string, tweetid int) error
+	GetBookmarks(ctx context.Context, username string, folderid *uint, cursor string, limit int) (*models.BookmarkPage, error)
+	AddBookmarkFolder(ctx context.Context, folder *models.BookmarkFolder) error
+	GetBookmarkFolders(ctx context.Context, username string) (*[]models.BookmarkFolder, error)
+	DeleteBookmarkFolder(ctx context.Context, username string, folderid int) error
+	AddList(ctx context.Context, list *models.List) error
+	GetList(ctx context.Context, viewer string, listid int) (*models.List, error)
+	GetListsOfUser(ctx context.Context, username string) (*[]models.List, error)
+	DeleteList(ctx context.Context, username string, listid int) error
+	AddListMember(ctx context.Context, username string, member *models.ListMember) error
+	DeleteListMember(ctx context.Context, username string, listid int, membername string) error
+	GetListMembers(ctx context.Context, viewer string, listid int) (*[]models.ListMember, error)
+	SubscribeList(ctx context.Context, username string, listid int) error
+	UnsubscribeList(ctx context.Context, username string, listid int) error
+	GetListTimeline(ctx context.Context, viewer string, listid int) (*[]models.Tweet, error)
+	GetPoll(ctx context.Context, viewer string, tweetid int) (*models.Poll, error)
+	VotePoll(ctx context.Context, username string, tweetid int, optionid uint) (*models.Poll, error)
+	GetSuggestions(ctx context.Context, username string) (*[]models.Suggestion, error)
+	DeactivateUser(ctx context.Context, user *models.User) error
+	RenameUser(ctx context.Context, user *models.User, newname string) error
+	GetRenamedUsername(ctx context.Context, oldname string) (string, error)
+	DisableUser(ctx context.Context, username string) error
+	EnableUser(ctx context.Context, username string) error
+	ResetPassword(ctx context.Context, username string, password string) error
+	ListUsers(ctx context.Context) (*[]models.User, error)
+	GetStats(ctx context.Context) (*models.Stats, error)
+	RepairFollows(ctx context.Context, dryRun bool) (*models.FollowRepair, error)
+	Import(ctx context.Context, kind string, format string, r io.Reader) (*models.ImportReport, error)
+	Export(ctx context.Context, kind string, format string, passwords bool, w io.Writer) error
+	RequestDataExport(ctx context.Context, username string) (*models.DataExport, error)
+	GetDataExport(ctx context.Context, username string) (*models.DataExport, error)
+	OpenDataExport(ctx context.Context, token string) (io.ReadCloser, error)
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"example/layered-architecture/models"
 	"example/layered-architecture/repositories"
@@ -31,16 +32,46 @@ func TestGetAllUsers(t *testing.T) {
 			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
 			mockRepository.
 				EXPECT().
-				GetAllUsers().
+				GetAllUsers(gomock.Any()).
 				Return(test.returnUsersFromRepository, test.returnErrorFromRepository).
 				Times(1)
 
-			ms := NewUserService(mockRepository)
+			ms := NewUserService(mockRepository, nil)
 
-			_, err := ms.GetAllUsers()
+			_, err := ms.GetAllUsers(context.Background())
 
 			assert.Equal(t, err, test.expectedError)
 		})
 	}
 
 }
+
+func TestCancelledContextReachesRepository(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		GetTweetsOfUser(ctx, "abc").
+		DoAndReturn(func(ctx context.Context, username string) (*[]models.Tweet, error) {
+			return nil, ctx.Err()
+		}).
+		Times(1)
+	ms := NewUserService(mockRepository, nil)
+
+	_, err := ms.GetTweetsOfUser(ctx, "abc")
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// expectTransactions lets the mock run transactions on itself, the calls made
+// inside them are expected on the mock as usual.
+func expectTransactions(mockRepository *repositories.MockRepositoryInterface) {
+	mockRepository.
+		EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(repositories.RepositoryInterface) error) error {
+			return fn(mockRepository)
+		}).
+		AnyTimes()
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	suggestionCandidates = 200
+	suggestionsPerUser   = 50
+	// suggestionTTL is how old suggestions may get even when nothing changed,
+	// since the follows of followees change without the user noticing.
+	suggestionTTL       = 24 * time.Hour
+	suggestionBatchSize = 100
+)
+
+// GetSuggestions returns who-to-follow suggestions from the cache. Only the
+// very first request of a user computes them inline, after that the
+// SuggestionWorker keeps them up to date.
+func (service *UserService) GetSuggestions(ctx context.Context, username string) (*[]models.Suggestion, error) {
+	state, err := service.repository.GetSuggestionState(ctx, username)
+	if err != nil {
+		return nil, translate(err)
+	}
+	if state == nil {
+		err = service.RefreshSuggestions(ctx, username, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return translated(service.repository.GetSuggestions(ctx, username, suggestionsPerUser))
+}
+
+// RefreshSuggestions recomputes and stores the suggestions of a user.
+func (service *UserService) RefreshSuggestions(ctx context.Context, username string, now time.Time) error {
+	candidates, err := service.repository.FindFollowCandidates(ctx, username, suggestionCandidates)
+	if err != nil {
+		return translate(err)
+	}
+	suggestions := scoreSuggestions(username, *candidates)
+	return translate(service.repository.SaveSuggestions(ctx, username, &suggestions, now))
+}
+
+// scoreSuggestions ranks candidates mostly by mutual follows, using the
+// follower count to break ties towards established accounts.
+func scoreSuggestions(username string, candidates []models.Suggestion) []models.Suggestion {
+	for i := range candidates {
+		candidates[i].UserName = username
+		candidates[i].Score = float64(candidates[i].Mutuals) + 0.1*math.Log1p(float64(candidates[i].Followers))
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	if len(candidates) > suggestionsPerUser {
+		candidates = candidates[:suggestionsPerUser]
+	}
+	return candidates
+}
+
+// SuggestionWorker recomputes suggestions that went stale because the user
+// followed or unfollowed someone, or simply got old.
+type SuggestionWorker struct {
+	service   *UserService
+	interval  time.Duration
+	heartbeat Heartbeat
+}
+
+func NewSuggestionWorker(service *UserService, interval time.Duration) *SuggestionWorker {
+	return &SuggestionWorker{service: service, interval: interval}
+}
+
+// Run refreshes a batch of stale suggestions every interval until ctx is
+// cancelled.
+func (worker *SuggestionWorker) Run(ctx context.Context) {
+	worker.heartbeat.start(worker.interval)
+	defer worker.heartbeat.stop()
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		roundCtx, span := startRound(ctx, "suggestions")
+		usernames, err := worker.service.repository.GetUsersWithStaleSuggestions(roundCtx, now.Add(-suggestionTTL), suggestionBatchSize)
+		if err != nil {
+			slog.ErrorContext(roundCtx, "cannot find stale suggestions", "error", err)
+			record(span, err)
+		}
+		for _, username := range usernames {
+			err = worker.service.RefreshSuggestions(roundCtx, username, now)
+			if err != nil {
+				slog.ErrorContext(roundCtx, "cannot refresh suggestions", "error", err)
+				record(span, err)
+			}
+		}
+		span.End()
+		worker.heartbeat.beat()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Heartbeat tells whether the worker is still refreshing suggestions.
+func (worker *SuggestionWorker) Heartbeat() *Heartbeat {
+	return &worker.heartbeat
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreSuggestions(t *testing.T) {
+	candidates := []models.Suggestion{
+		{Candidate: "popular", Mutuals: 1, Followers: 10000},
+		{Candidate: "close", Mutuals: 3, Followers: 2},
+		{Candidate: "niche", Mutuals: 1, Followers: 1}}
+
+	scored := scoreSuggestions("abc", candidates)
+
+	assert.Equal(t, "close", scored[0].Candidate)
+	assert.Equal(t, "popular", scored[1].Candidate)
+	assert.Equal(t, "niche", scored[2].Candidate)
+	assert.Equal(t, "abc", scored[2].UserName)
+}
+
+func TestGetSuggestions(t *testing.T) {
+	type testCase struct {
+		name              string
+		state             *models.SuggestionState
+		expectedRefreshes int
+	}
+	testCases := []testCase{{name: "never computed",
+		state:             nil,
+		expectedRefreshes: 1},
+		{name: "cached",
+			state:             &models.SuggestionState{UserName: "abc", ComputedAt: time.Now(), Stale: true},
+			expectedRefreshes: 0}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.EXPECT().GetSuggestionState(gomock.Any(), "abc").Return(test.state, nil).Times(1)
+			mockRepository.
+				EXPECT().
+				FindFollowCandidates(gomock.Any(), "abc", suggestionCandidates).
+				Return(&[]models.Suggestion{}, nil).
+				Times(test.expectedRefreshes)
+			mockRepository.
+				EXPECT().
+				SaveSuggestions(gomock.Any(), "abc", gomock.Any(), gomock.Any()).
+				Return(nil).
+				Times(test.expectedRefreshes)
+			mockRepository.
+				EXPECT().
+				GetSuggestions(gomock.Any(), "abc", suggestionsPerUser).
+				Return(&[]models.Suggestion{}, nil).
+				Times(1)
+			ms := NewUserService(mockRepository, nil)
+
+			_, err := ms.GetSuggestions(context.Background(), "abc")
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestAddFolloweeMarksSuggestionsStale(t *testing.T) {
+	follow := models.Follows{SourceUser: "abc", TargetUser: "def"}
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	expectTransactions(mockRepository)
+	mockRepository.EXPECT().AddFollowee(gomock.Any(), &follow).Return(nil).Times(1)
+	mockRepository.EXPECT().MarkSuggestionsStale(gomock.Any(), "abc").Return(nil).Times(1)
+	ms := NewUserService(mockRepository, nil)
+
+	err := ms.AddFollowee(context.Background(), &follow)
+
+	assert.Nil(t, err)
+}
+
+func TestAddFolloweeTwice(t *testing.T) {
+	follow := models.Follows{SourceUser: "abc", TargetUser: "def"}
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	expectTransactions(mockRepository)
+	mockRepository.EXPECT().AddFollowee(gomock.Any(), &follow).Return(fmt.Errorf("follow of %q: %w", "def", repositories.ErrConflict)).Times(1)
+	mockRepository.EXPECT().MarkSuggestionsStale(gomock.Any(), "abc").Times(0)
+	ms := NewUserService(mockRepository, nil)
+
+	err := ms.AddFollowee(context.Background(), &follow)
+
+	var serviceErr *Error
+	assert.True(t, errors.As(err, &serviceErr))
+	assert.Equal(t, CodeConflict, serviceErr.Code)
+}
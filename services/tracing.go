@@ -0,0 +1,414 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("example/layered-architecture/services")
+
+// tracedService wraps every method of a service in a span, with the users and
+// ids the call is about as attributes. Passwords and tokens are left out.
+type tracedService struct {
+	service ServiceInterface
+}
+
+// WithTracing returns service with a span around every call.
+func WithTracing(service ServiceInterface) ServiceInterface {
+	return &tracedService{service: service}
+}
+
+// start starts the span of a call to method.
+func (traced *tracedService) start(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "UserService."+method, trace.WithAttributes(attrs...))
+}
+
+// startRound starts the span of a round of a background worker. Rounds are
+// not part of any request, every one starts a trace of its own.
+func startRound(ctx context.Context, worker string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, worker+" round", trace.WithNewRoot())
+}
+
+// record marks span as failed with err, if there is one.
+func record(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// end records how the call went and ends its span.
+func end(span trace.Span, err error) {
+	record(span, err)
+	span.End()
+}
+
+func (traced *tracedService) AddUser(ctx context.Context, user *models.User) error {
+	ctx, span := traced.start(ctx, "AddUser", attribute.String("username", user.Name))
+	err := traced.service.AddUser(ctx, user)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) SignIn(ctx context.Context, user *models.User) error {
+	ctx, span := traced.start(ctx, "SignIn", attribute.String("username", user.Name))
+	err := traced.service.SignIn(ctx, user)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetAllUsers(ctx context.Context) (*[]models.User, error) {
+	ctx, span := traced.start(ctx, "GetAllUsers")
+	result, err := traced.service.GetAllUsers(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) AddTweet(ctx context.Context, tweet *models.Tweet) error {
+	ctx, span := traced.start(ctx, "AddTweet", attribute.String("username", tweet.UserName))
+	err := traced.service.AddTweet(ctx, tweet)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetTweetsOfUser(ctx context.Context, username string) (*[]models.Tweet, error) {
+	ctx, span := traced.start(ctx, "GetTweetsOfUser", attribute.String("username", username))
+	result, err := traced.service.GetTweetsOfUser(ctx, username)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) GetFolloweesOfUser(ctx context.Context, username string) (*[]models.Follows, error) {
+	ctx, span := traced.start(ctx, "GetFolloweesOfUser", attribute.String("username", username))
+	result, err := traced.service.GetFolloweesOfUser(ctx, username)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) AddFollowee(ctx context.Context, follow *models.Follows) error {
+	ctx, span := traced.start(ctx, "AddFollowee", attribute.String("username", follow.SourceUser), attribute.String("followeename", follow.TargetUser))
+	err := traced.service.AddFollowee(ctx, follow)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) DeleteTweet(ctx context.Context, tweetid int) error {
+	ctx, span := traced.start(ctx, "DeleteTweet", attribute.Int("tweetid", tweetid))
+	err := traced.service.DeleteTweet(ctx, tweetid)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) DeleteFollowee(ctx context.Context, username string, followeename string) error {
+	ctx, span := traced.start(ctx, "DeleteFollowee", attribute.String("username", username), attribute.String("followeename", followeename))
+	err := traced.service.DeleteFollowee(ctx, username, followeename)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetRelationship(ctx context.Context, source string, target string) (*models.Relationship, error) {
+	ctx, span := traced.start(ctx, "GetRelationship", attribute.String("source", source), attribute.String("target", target))
+	result, err := traced.service.GetRelationship(ctx, source, target)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) UploadMedia(ctx context.Context, username string, data io.Reader) (*models.Media, error) {
+	ctx, span := traced.start(ctx, "UploadMedia", attribute.String("username", username))
+	result, err := traced.service.UploadMedia(ctx, username, data)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) GetMedia(ctx context.Context, mediaid int, thumbnail bool) (*models.Media, io.ReadCloser, error) {
+	ctx, span := traced.start(ctx, "GetMedia", attribute.Int("mediaid", mediaid))
+	result, content, err := traced.service.GetMedia(ctx, mediaid, thumbnail)
+	end(span, err)
+	return result, content, err
+}
+
+func (traced *tracedService) AddDraft(ctx context.Context, draft *models.Draft) error {
+	ctx, span := traced.start(ctx, "AddDraft", attribute.String("username", draft.UserName))
+	err := traced.service.AddDraft(ctx, draft)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetDraftsOfUser(ctx context.Context, username string) (*[]models.Draft, error) {
+	ctx, span := traced.start(ctx, "GetDraftsOfUser", attribute.String("username", username))
+	result, err := traced.service.GetDraftsOfUser(ctx, username)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) GetDraft(ctx context.Context, username string, draftid int) (*models.Draft, error) {
+	ctx, span := traced.start(ctx, "GetDraft", attribute.String("username", username), attribute.Int("draftid", draftid))
+	result, err := traced.service.GetDraft(ctx, username, draftid)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) UpdateDraft(ctx context.Context, username string, draft *models.Draft) error {
+	ctx, span := traced.start(ctx, "UpdateDraft", attribute.String("username", username), attribute.String("username", draft.UserName))
+	err := traced.service.UpdateDraft(ctx, username, draft)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) DeleteDraft(ctx context.Context, username string, draftid int) error {
+	ctx, span := traced.start(ctx, "DeleteDraft", attribute.String("username", username), attribute.Int("draftid", draftid))
+	err := traced.service.DeleteDraft(ctx, username, draftid)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) PinTweet(ctx context.Context, username string, tweetid int) error {
+	ctx, span := traced.start(ctx, "PinTweet", attribute.String("username", username), attribute.Int("tweetid", tweetid))
+	err := traced.service.PinTweet(ctx, username, tweetid)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) UnpinTweet(ctx context.Context, username string) error {
+	ctx, span := traced.start(ctx, "UnpinTweet", attribute.String("username", username))
+	err := traced.service.UnpinTweet(ctx, username)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) AddBookmark(ctx context.Context, bookmark *models.Bookmark) error {
+	ctx, span := traced.start(ctx, "AddBookmark", attribute.String("username", bookmark.UserName), attribute.Int("tweetid", int(bookmark.TweetID)))
+	err := traced.service.AddBookmark(ctx, bookmark)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) DeleteBookmark(ctx context.Context, username string, tweetid int) error {
+	ctx, span := traced.start(ctx, "DeleteBookmark", attribute.String("username", username), attribute.Int("tweetid", tweetid))
+	err := traced.service.DeleteBookmark(ctx, username, tweetid)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetBookmarks(ctx context.Context, username string, folderid *uint, cursor string, limit int) (*models.BookmarkPage, error) {
+	ctx, span := traced.start(ctx, "GetBookmarks", attribute.String("username", username))
+	result, err := traced.service.GetBookmarks(ctx, username, folderid, cursor, limit)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) AddBookmarkFolder(ctx context.Context, folder *models.BookmarkFolder) error {
+	ctx, span := traced.start(ctx, "AddBookmarkFolder", attribute.String("username", folder.UserName))
+	err := traced.service.AddBookmarkFolder(ctx, folder)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetBookmarkFolders(ctx context.Context, username string) (*[]models.BookmarkFolder, error) {
+	ctx, span := traced.start(ctx, "GetBookmarkFolders", attribute.String("username", username))
+	result, err := traced.service.GetBookmarkFolders(ctx, username)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) DeleteBookmarkFolder(ctx context.Context, username string, folderid int) error {
+	ctx, span := traced.start(ctx, "DeleteBookmarkFolder", attribute.String("username", username), attribute.Int("folderid", folderid))
+	err := traced.service.DeleteBookmarkFolder(ctx, username, folderid)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) AddList(ctx context.Context, list *models.List) error {
+	ctx, span := traced.start(ctx, "AddList", attribute.String("username", list.OwnerName))
+	err := traced.service.AddList(ctx, list)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetList(ctx context.Context, viewer string, listid int) (*models.List, error) {
+	ctx, span := traced.start(ctx, "GetList", attribute.String("viewer", viewer), attribute.Int("listid", listid))
+	result, err := traced.service.GetList(ctx, viewer, listid)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) GetListsOfUser(ctx context.Context, username string) (*[]models.List, error) {
+	ctx, span := traced.start(ctx, "GetListsOfUser", attribute.String("username", username))
+	result, err := traced.service.GetListsOfUser(ctx, username)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) DeleteList(ctx context.Context, username string, listid int) error {
+	ctx, span := traced.start(ctx, "DeleteList", attribute.String("username", username), attribute.Int("listid", listid))
+	err := traced.service.DeleteList(ctx, username, listid)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) AddListMember(ctx context.Context, username string, member *models.ListMember) error {
+	ctx, span := traced.start(ctx, "AddListMember", attribute.String("username", username), attribute.String("membername", member.UserName))
+	err := traced.service.AddListMember(ctx, username, member)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) DeleteListMember(ctx context.Context, username string, listid int, membername string) error {
+	ctx, span := traced.start(ctx, "DeleteListMember", attribute.String("username", username), attribute.Int("listid", listid), attribute.String("membername", membername))
+	err := traced.service.DeleteListMember(ctx, username, listid, membername)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetListMembers(ctx context.Context, viewer string, listid int) (*[]models.ListMember, error) {
+	ctx, span := traced.start(ctx, "GetListMembers", attribute.String("viewer", viewer), attribute.Int("listid", listid))
+	result, err := traced.service.GetListMembers(ctx, viewer, listid)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) SubscribeList(ctx context.Context, username string, listid int) error {
+	ctx, span := traced.start(ctx, "SubscribeList", attribute.String("username", username), attribute.Int("listid", listid))
+	err := traced.service.SubscribeList(ctx, username, listid)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) UnsubscribeList(ctx context.Context, username string, listid int) error {
+	ctx, span := traced.start(ctx, "UnsubscribeList", attribute.String("username", username), attribute.Int("listid", listid))
+	err := traced.service.UnsubscribeList(ctx, username, listid)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetListTimeline(ctx context.Context, viewer string, listid int) (*[]models.Tweet, error) {
+	ctx, span := traced.start(ctx, "GetListTimeline", attribute.String("viewer", viewer), attribute.Int("listid", listid))
+	result, err := traced.service.GetListTimeline(ctx, viewer, listid)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) GetPoll(ctx context.Context, viewer string, tweetid int) (*models.Poll, error) {
+	ctx, span := traced.start(ctx, "GetPoll", attribute.String("viewer", viewer), attribute.Int("tweetid", tweetid))
+	result, err := traced.service.GetPoll(ctx, viewer, tweetid)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) VotePoll(ctx context.Context, username string, tweetid int, optionid uint) (*models.Poll, error) {
+	ctx, span := traced.start(ctx, "VotePoll", attribute.String("username", username), attribute.Int("tweetid", tweetid), attribute.Int("optionid", int(optionid)))
+	result, err := traced.service.VotePoll(ctx, username, tweetid, optionid)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) GetSuggestions(ctx context.Context, username string) (*[]models.Suggestion, error) {
+	ctx, span := traced.start(ctx, "GetSuggestions", attribute.String("username", username))
+	result, err := traced.service.GetSuggestions(ctx, username)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) DeactivateUser(ctx context.Context, user *models.User) error {
+	ctx, span := traced.start(ctx, "DeactivateUser", attribute.String("username", user.Name))
+	err := traced.service.DeactivateUser(ctx, user)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) RenameUser(ctx context.Context, user *models.User, newname string) error {
+	ctx, span := traced.start(ctx, "RenameUser", attribute.String("username", user.Name), attribute.String("newname", newname))
+	err := traced.service.RenameUser(ctx, user, newname)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) GetRenamedUsername(ctx context.Context, oldname string) (string, error) {
+	ctx, span := traced.start(ctx, "GetRenamedUsername", attribute.String("oldname", oldname))
+	result, err := traced.service.GetRenamedUsername(ctx, oldname)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) DisableUser(ctx context.Context, username string) error {
+	ctx, span := traced.start(ctx, "DisableUser", attribute.String("username", username))
+	err := traced.service.DisableUser(ctx, username)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) EnableUser(ctx context.Context, username string) error {
+	ctx, span := traced.start(ctx, "EnableUser", attribute.String("username", username))
+	err := traced.service.EnableUser(ctx, username)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) ResetPassword(ctx context.Context, username string, password string) error {
+	ctx, span := traced.start(ctx, "ResetPassword", attribute.String("username", username))
+	err := traced.service.ResetPassword(ctx, username, password)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) ListUsers(ctx context.Context) (*[]models.User, error) {
+	ctx, span := traced.start(ctx, "ListUsers")
+	result, err := traced.service.ListUsers(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) GetStats(ctx context.Context) (*models.Stats, error) {
+	ctx, span := traced.start(ctx, "GetStats")
+	result, err := traced.service.GetStats(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) RepairFollows(ctx context.Context, dryRun bool) (*models.FollowRepair, error) {
+	ctx, span := traced.start(ctx, "RepairFollows")
+	result, err := traced.service.RepairFollows(ctx, dryRun)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) Import(ctx context.Context, kind string, format string, r io.Reader) (*models.ImportReport, error) {
+	ctx, span := traced.start(ctx, "Import", attribute.String("kind", kind), attribute.String("format", format))
+	result, err := traced.service.Import(ctx, kind, format, r)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) Export(ctx context.Context, kind string, format string, passwords bool, w io.Writer) error {
+	ctx, span := traced.start(ctx, "Export", attribute.String("kind", kind), attribute.String("format", format), attribute.Bool("passwords", passwords))
+	err := traced.service.Export(ctx, kind, format, passwords, w)
+	end(span, err)
+	return err
+}
+
+func (traced *tracedService) RequestDataExport(ctx context.Context, username string) (*models.DataExport, error) {
+	ctx, span := traced.start(ctx, "RequestDataExport", attribute.String("username", username))
+	result, err := traced.service.RequestDataExport(ctx, username)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) GetDataExport(ctx context.Context, username string) (*models.DataExport, error) {
+	ctx, span := traced.start(ctx, "GetDataExport", attribute.String("username", username))
+	result, err := traced.service.GetDataExport(ctx, username)
+	end(span, err)
+	return result, err
+}
+
+func (traced *tracedService) OpenDataExport(ctx context.Context, token string) (io.ReadCloser, error) {
+	ctx, span := traced.start(ctx, "OpenDataExport")
+	result, err := traced.service.OpenDataExport(ctx, token)
+	end(span, err)
+	return result, err
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	mockCtrl := gomock.NewController(t)
+	mockService := NewMockServiceInterface(mockCtrl)
+	service := WithTracing(mockService)
+
+	mockService.EXPECT().SignIn(gomock.Any(), gomock.Any()).Return(repositories.ErrUnauthorized)
+	mockService.EXPECT().DeleteTweet(gomock.Any(), 5).Return(nil)
+
+	err := service.SignIn(context.Background(), &models.User{Name: "abc", Password: "hunter2"})
+	assert.ErrorIs(t, err, repositories.ErrUnauthorized)
+	err = service.DeleteTweet(context.Background(), 5)
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "UserService.SignIn", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 1)
+	//the password is never an attribute
+	assert.Equal(t, []attribute.KeyValue{attribute.String("username", "abc")}, spans[0].Attributes())
+	assert.Equal(t, "UserService.DeleteTweet", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("tweetid", 5)}, spans[1].Attributes())
+}
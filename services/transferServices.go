@@ -0,0 +1,359 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"example/layered-architecture/models"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	exportBatchSize         = 500
+	maxReportedImportErrors = 100
+	// maxImportLine is the longest NDJSON line accepted.
+	maxImportLine = 1 << 20
+)
+
+// Records as they are imported and exported. Users are referenced by name
+// rather than id, so data can move between databases.
+type userRecord struct {
+	Name      string    `json:"name"`
+	Password  string    `json:"password"`
+	CreatedAt time.Time `json:"createdat"`
+	UpdatedAt time.Time `json:"updatedat"`
+}
+
+type tweetRecord struct {
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdat"`
+	UpdatedAt time.Time `json:"updatedat"`
+}
+
+type followRecord struct {
+	SourceUser string    `json:"sourceuser"`
+	TargetUser string    `json:"targetuser"`
+	CreatedAt  time.Time `json:"createdat"`
+	UpdatedAt  time.Time `json:"updatedat"`
+}
+
+// transferKind describes one kind of record: its CSV columns, how to store a
+// decoded record and how to read a batch of records after an id.
+type transferKind struct {
+	columns      []string
+	importRecord func(ctx context.Context, service *UserService, data []byte) error
+	exportBatch  func(ctx context.Context, service *UserService, afterID uint) ([]interface{}, uint, error)
+}
+
+var (
+	unknownTransferKind   = ValidationError("unknown kind", map[string]string{"kind": "must be users, tweets or follows"})
+	unknownTransferFormat = ValidationError("unknown format", map[string]string{"format": "must be ndjson or csv"})
+)
+
+var transferKinds = map[string]transferKind{
+	"users": {
+		columns:      []string{"name", "password", "createdat", "updatedat"},
+		importRecord: importUser,
+		exportBatch:  exportUsers,
+	},
+	"tweets": {
+		columns:      []string{"name", "content", "createdat", "updatedat"},
+		importRecord: importTweet,
+		exportBatch:  exportTweets,
+	},
+	"follows": {
+		columns:      []string{"sourceuser", "targetuser", "createdat", "updatedat"},
+		importRecord: importFollow,
+		exportBatch:  exportFollows,
+	},
+}
+
+// Import reads users, tweets or follows in NDJSON or CSV format and stores
+// them, keeping their timestamps. A bad row is reported with its line and
+// does not stop the import. CSV input starts with a header row naming the
+// columns.
+func (service *UserService) Import(ctx context.Context, kind string, format string, r io.Reader) (*models.ImportReport, error) {
+	transfer, ok := transferKinds[kind]
+	if !ok {
+		return nil, unknownTransferKind
+	}
+	report := models.ImportReport{Errors: []models.ImportError{}}
+	record := func(line int, data []byte, err error) {
+		if err == nil {
+			err = transfer.importRecord(ctx, service, data)
+		}
+		if err == nil {
+			report.Imported++
+			return
+		}
+		report.Failed++
+		if len(report.Errors) < maxReportedImportErrors {
+			report.Errors = append(report.Errors, models.ImportError{Line: line, Error: err.Error()})
+		}
+	}
+	var err error
+	switch format {
+	case "ndjson":
+		err = readNDJSON(r, record)
+	case "csv":
+		err = readCSV(r, transfer.columns, record)
+	default:
+		return nil, unknownTransferFormat
+	}
+	//rows keep failing once the request is cancelled, report why
+	if err == nil {
+		err = ctx.Err()
+	}
+	return &report, err
+}
+
+// Export writes every user, tweet or follow in NDJSON or CSV format. Unknown
+// kinds and formats are rejected before anything is written. Passwords are
+// stored as they were given, so users are only exported with them when
+// passwords is set; without them the users cannot be imported again.
+func (service *UserService) Export(ctx context.Context, kind string, format string, passwords bool, w io.Writer) error {
+	transfer, ok := transferKinds[kind]
+	if !ok {
+		return unknownTransferKind
+	}
+	var write func(record interface{}) error
+	flush := func() error { return nil }
+	switch format {
+	case "ndjson":
+		write = json.NewEncoder(w).Encode
+	case "csv":
+		//nothing is flushed on errors, so that an export failing in its
+		//first batch has not written anything yet
+		writer := csv.NewWriter(w)
+		err := writer.Write(transfer.columns)
+		if err != nil {
+			return err
+		}
+		write = func(record interface{}) error {
+			row, err := csvRow(record, transfer.columns)
+			if err != nil {
+				return err
+			}
+			return writer.Write(row)
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	default:
+		return unknownTransferFormat
+	}
+
+	var afterID uint
+	for {
+		records, lastID, err := transfer.exportBatch(ctx, service, afterID)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return flush()
+		}
+		for _, record := range records {
+			if user, ok := record.(userRecord); ok && !passwords {
+				user.Password = ""
+				record = user
+			}
+			err = write(record)
+			if err != nil {
+				return err
+			}
+		}
+		afterID = lastID
+	}
+}
+
+func readNDJSON(r io.Reader, record func(line int, data []byte, err error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLine)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		record(line, data, nil)
+	}
+	return scanner.Err()
+}
+
+// readCSV turns every row into a JSON object keyed by the header, so both
+// formats are validated the same way. Empty cells are left out.
+func readCSV(r io.Reader, columns []string, record func(line int, data []byte, err error)) error {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[column] = true
+	}
+	for _, column := range header {
+		if !known[column] {
+			return ValidationError(fmt.Sprintf("unknown column %q", column), map[string]string{"header": "columns are " + strings.Join(columns, ", ")})
+		}
+	}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			record(parseErr.StartLine, nil, parseErr.Err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		fields := make(map[string]string, len(row))
+		for i, value := range row {
+			if value != "" {
+				fields[header[i]] = value
+			}
+		}
+		data, err := json.Marshal(fields)
+		record(line, data, err)
+	}
+}
+
+func csvRow(record interface{}, columns []string) ([]string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	//every record field is a string or a time, which marshals to a string
+	var fields map[string]string
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		row[i] = fields[column]
+	}
+	return row, nil
+}
+
+func decodeRecord(data []byte, record interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(record)
+}
+
+func importUser(ctx context.Context, service *UserService, data []byte) error {
+	var record userRecord
+	err := decodeRecord(data, &record)
+	if err != nil {
+		return err
+	}
+	//imported accounts keep their password, only new passwords have to
+	//follow the policy
+	v := validation{}
+	v.check("name", record.Name, usernameRules...)
+	v.check("password", record.Password, required)
+	err = v.err("invalid user")
+	if err != nil {
+		return err
+	}
+	user := models.User{
+		Model:    gorm.Model{CreatedAt: record.CreatedAt, UpdatedAt: record.UpdatedAt},
+		Name:     record.Name,
+		Password: record.Password,
+	}
+	return translate(service.repository.AddUser(ctx, &user))
+}
+
+func importTweet(ctx context.Context, service *UserService, data []byte) error {
+	var record tweetRecord
+	err := decodeRecord(data, &record)
+	if err != nil {
+		return err
+	}
+	v := validation{}
+	v.check("name", record.Name, required)
+	v.check("content", record.Content, tweetRules...)
+	err = v.err("invalid tweet")
+	if err != nil {
+		return err
+	}
+	tweet := models.Tweet{
+		Model:    gorm.Model{CreatedAt: record.CreatedAt, UpdatedAt: record.UpdatedAt},
+		UserName: record.Name,
+		Content:  record.Content,
+	}
+	return translate(service.repository.ImportTweet(ctx, &tweet))
+}
+
+func importFollow(ctx context.Context, service *UserService, data []byte) error {
+	var record followRecord
+	err := decodeRecord(data, &record)
+	if err != nil {
+		return err
+	}
+	follow := models.Follows{
+		Model:      gorm.Model{CreatedAt: record.CreatedAt, UpdatedAt: record.UpdatedAt},
+		SourceUser: record.SourceUser,
+		TargetUser: record.TargetUser,
+	}
+	err = validateFollow(&follow)
+	if err != nil {
+		return err
+	}
+	if record.SourceUser == record.TargetUser {
+		return ValidationError("users cannot follow themselves", nil)
+	}
+	return translate(service.repository.ImportFollow(ctx, &follow))
+}
+
+func exportUsers(ctx context.Context, service *UserService, afterID uint) ([]interface{}, uint, error) {
+	users, err := service.repository.ExportUsers(ctx, afterID, exportBatchSize)
+	if err != nil || len(*users) == 0 {
+		return nil, afterID, translate(err)
+	}
+	records := make([]interface{}, 0, len(*users))
+	for _, user := range *users {
+		records = append(records, userRecord{Name: user.Name, Password: user.Password, CreatedAt: user.CreatedAt, UpdatedAt: user.UpdatedAt})
+	}
+	return records, (*users)[len(*users)-1].ID, nil
+}
+
+func exportTweets(ctx context.Context, service *UserService, afterID uint) ([]interface{}, uint, error) {
+	tweets, err := service.repository.ExportTweets(ctx, afterID, exportBatchSize)
+	if err != nil || len(*tweets) == 0 {
+		return nil, afterID, translate(err)
+	}
+	records := make([]interface{}, 0, len(*tweets))
+	for _, tweet := range *tweets {
+		records = append(records, tweetRecord{Name: tweet.UserName, Content: tweet.Content, CreatedAt: tweet.CreatedAt, UpdatedAt: tweet.UpdatedAt})
+	}
+	return records, (*tweets)[len(*tweets)-1].ID, nil
+}
+
+func exportFollows(ctx context.Context, service *UserService, afterID uint) ([]interface{}, uint, error) {
+	follows, err := service.repository.ExportFollows(ctx, afterID, exportBatchSize)
+	if err != nil || len(*follows) == 0 {
+		return nil, afterID, translate(err)
+	}
+	records := make([]interface{}, 0, len(*follows))
+	for _, follow := range *follows {
+		records = append(records, followRecord{SourceUser: follow.SourceUser, TargetUser: follow.TargetUser, CreatedAt: follow.CreatedAt, UpdatedAt: follow.UpdatedAt})
+	}
+	return records, (*follows)[len(*follows)-1].ID, nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportNDJSON(t *testing.T) {
+	input := strings.Join([]string{
+		`{"name":"abc","content":"hello","createdat":"2020-01-02T03:04:05Z"}`,
+		``,
+		`{"name":"abc","content":""}`,
+		`not json`,
+		`{"name":"abc","content":"hi","extra":1}`,
+		`{"name":"nobody","content":"hi"}`,
+	}, "\n")
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.
+		EXPECT().
+		ImportTweet(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, tweet *models.Tweet) error {
+			if tweet.UserName == "nobody" {
+				return errors.New("unknown user")
+			}
+			assert.Equal(t, created, tweet.CreatedAt)
+			return nil
+		}).
+		Times(2)
+	ms := NewUserService(mockRepository, nil)
+
+	report, err := ms.Import(context.Background(), "tweets", "ndjson", strings.NewReader(input))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, 4, report.Failed)
+	lines := []int{}
+	for _, importErr := range report.Errors {
+		lines = append(lines, importErr.Line)
+	}
+	assert.Equal(t, []int{3, 4, 5, 6}, lines)
+}
+
+func TestImportCSV(t *testing.T) {
+	type testCase struct {
+		name             string
+		input            string
+		expectedCalls    int
+		expectedImported int
+		expectedFailed   int
+		expectedError    bool
+	}
+	testCases := []testCase{{name: "unknown column",
+		input:         "sourceuser,who\nabc,def\n",
+		expectedError: true},
+		{name: "bad rows",
+			input:            "sourceuser,targetuser\nabc,def\nabc,abc\nabc\n,def\n",
+			expectedCalls:    1,
+			expectedImported: 1,
+			expectedFailed:   3}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			mockRepository.
+				EXPECT().
+				ImportFollow(gomock.Any(), &models.Follows{SourceUser: "abc", TargetUser: "def"}).
+				Return(nil).
+				Times(test.expectedCalls)
+			ms := NewUserService(mockRepository, nil)
+
+			report, err := ms.Import(context.Background(), "follows", "csv", strings.NewReader(test.input))
+
+			assert.Equal(t, test.expectedError, err != nil)
+			if err == nil {
+				assert.Equal(t, test.expectedImported, report.Imported)
+				assert.Equal(t, test.expectedFailed, report.Failed)
+			}
+		})
+	}
+}
+
+func TestImportUnknownKind(t *testing.T) {
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	ms := NewUserService(mockRepository, nil)
+
+	_, err := ms.Import(context.Background(), "likes", "ndjson", strings.NewReader(""))
+
+	assert.NotNil(t, err)
+}
+
+func TestExportCSV(t *testing.T) {
+	type testCase struct {
+		name         string
+		passwords    bool
+		expectedLine string
+	}
+	testCases := []testCase{{name: "without passwords",
+		passwords:    false,
+		expectedLine: "abc,,2020-01-02T03:04:05Z,0001-01-01T00:00:00Z"},
+		{name: "with passwords",
+			passwords:    true,
+			expectedLine: "abc,secret,2020-01-02T03:04:05Z,0001-01-01T00:00:00Z"}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			users := []models.User{{Name: "abc", Password: "secret"}, {Name: "def", Password: "secret"}}
+			users[0].ID = 1
+			users[1].ID = 7
+			users[0].CreatedAt = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			gomock.InOrder(
+				mockRepository.EXPECT().ExportUsers(gomock.Any(), uint(0), exportBatchSize).Return(&users, nil),
+				mockRepository.EXPECT().ExportUsers(gomock.Any(), uint(7), exportBatchSize).Return(&[]models.User{}, nil),
+			)
+			ms := NewUserService(mockRepository, nil)
+			out := &bytes.Buffer{}
+
+			err := ms.Export(context.Background(), "users", "csv", test.passwords, out)
+
+			assert.Nil(t, err)
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			assert.Equal(t, "name,password,createdat,updatedat", lines[0])
+			assert.Equal(t, test.expectedLine, lines[1])
+			assert.Len(t, lines, 3)
+		})
+	}
+}
+
+func TestExportCSVFailsBeforeWriting(t *testing.T) {
+	mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+	mockRepository.EXPECT().ExportUsers(gomock.Any(), uint(0), exportBatchSize).Return(nil, errors.New("connection lost"))
+	ms := NewUserService(mockRepository, nil)
+	out := &bytes.Buffer{}
+
+	err := ms.Export(context.Background(), "users", "csv", false, out)
+
+	//not even the header, so that the error can still be the response
+	assert.Error(t, err)
+	assert.Empty(t, out.String())
+}
@@ -1,54 +1,115 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"example/layered-architecture/models"
 	"example/layered-architecture/repositories"
+	"example/layered-architecture/storage"
+	"time"
 )
 
 type UserService struct {
 	repository repositories.RepositoryInterface
+	blobs      storage.BlobStore
 }
 
-func NewUserService(repository repositories.RepositoryInterface) *UserService {
-	return &UserService{repository: repository}
+func NewUserService(repository repositories.RepositoryInterface, blobs storage.BlobStore) *UserService {
+	return &UserService{repository: repository, blobs: blobs}
 }
 
-func (service *UserService) AddUser(user *models.User) error {
-	return service.repository.AddUser(user)
+func (service *UserService) AddUser(ctx context.Context, user *models.User) error {
+	err := validateUser(user)
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.AddUser(ctx, user))
 }
 
-func (service *UserService) SignIn(user *models.User) error {
-	return service.repository.SignIn(user)
+func (service *UserService) SignIn(ctx context.Context, user *models.User) error {
+	err := service.repository.SignIn(ctx, user)
+	if errors.Is(err, repositories.ErrUnauthorized) {
+		signInFailures.Inc()
+	}
+	if err != nil {
+		return translate(err)
+	}
+	//signing in during the grace period cancels a pending account deletion
+	return translate(service.repository.ReactivateUser(ctx, user.Name))
 }
 
-func (service *UserService) GetAllUsers() (*[]models.User, error) {
-	return service.repository.GetAllUsers()
+func (service *UserService) GetAllUsers(ctx context.Context) (*[]models.User, error) {
+	return translated(service.repository.GetAllUsers(ctx))
 }
 
-func (service *UserService) AddTweet(tweet *models.Tweet) error {
-	return service.repository.AddTweet(tweet)
+func (service *UserService) AddTweet(ctx context.Context, tweet *models.Tweet) error {
+	err := validateTweet(tweet)
+	if err != nil {
+		return err
+	}
+	if tweet.Poll != nil {
+		err = preparePoll(tweet.Poll, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+	err = service.repository.AddTweet(ctx, tweet)
+	if err != nil {
+		return translate(err)
+	}
+	tweetsCreated.Inc()
+	return nil
 }
 
-func (service *UserService) GetTweetsOfUser(username string) (*[]models.Tweet, error) {
-	return service.repository.GetTweetsOfUser(username)
+func (service *UserService) GetTweetsOfUser(ctx context.Context, username string) (*[]models.Tweet, error) {
+	return translated(service.repository.GetTweetsOfUser(ctx, username))
 }
 
-func (service *UserService) GetFolloweesOfUser(username string) (*[]models.Follows, error) {
-	return service.repository.GetFolloweesOfUser(username)
+func (service *UserService) GetFolloweesOfUser(ctx context.Context, username string) (*[]models.Follows, error) {
+	return translated(service.repository.GetFolloweesOfUser(ctx, username))
 }
 
-func (service *UserService) AddFollowee(follow *models.Follows) error {
-	return service.repository.AddFollowee(follow)
+func (service *UserService) AddFollowee(ctx context.Context, follow *models.Follows) error {
+	err := validateFollow(follow)
+	if err != nil {
+		return err
+	}
+	err = service.repository.WithinTransaction(ctx, func(repository repositories.RepositoryInterface) error {
+		err := repository.AddFollowee(ctx, follow)
+		if err != nil {
+			return err
+		}
+		return repository.MarkSuggestionsStale(ctx, follow.SourceUser)
+	})
+	if err != nil {
+		return translate(err)
+	}
+	followsAdded.Inc()
+	return nil
 }
 
-func (service *UserService) DeleteTweet(tweetid int) error {
-	return service.repository.DeleteTweet(tweetid)
+func (service *UserService) DeleteTweet(ctx context.Context, tweetid int) error {
+	return translate(service.repository.DeleteTweet(ctx, tweetid))
 }
 
-func (service *UserService) DeleteFollowee(username string, followeename string) error {
-	return service.repository.DeleteFollowee(username, followeename)
+func (service *UserService) DeleteFollowee(ctx context.Context, username string, followeename string) error {
+	return translate(service.repository.WithinTransaction(ctx, func(repository repositories.RepositoryInterface) error {
+		err := repository.DeleteFollowee(ctx, username, followeename)
+		if err != nil {
+			return err
+		}
+		return repository.MarkSuggestionsStale(ctx, username)
+	}))
 }
 
-func (service *UserService) CheckFollowing(username string, followeename string) error {
-	return service.repository.CheckFollowing(username, followeename)
+func (service *UserService) GetRelationship(ctx context.Context, source string, target string) (*models.Relationship, error) {
+	return translated(service.repository.GetRelationship(ctx, source, target))
+}
+
+func (service *UserService) PinTweet(ctx context.Context, username string, tweetid int) error {
+	return translate(service.repository.PinTweet(ctx, username, tweetid))
+}
+
+func (service *UserService) UnpinTweet(ctx context.Context, username string) error {
+	return translate(service.repository.UnpinTweet(ctx, username))
 }
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"time"
+)
+
+// usernameCooldown is how long an old handle keeps redirecting and stays
+// reserved for the user who gave it up.
+const usernameCooldown = 30 * 24 * time.Hour
+
+// RenameUser changes the handle of user after checking its password.
+func (service *UserService) RenameUser(ctx context.Context, user *models.User, newname string) error {
+	v := validation{}
+	v.check("newname", newname, usernameRules...)
+	if newname == user.Name {
+		v.fail("newname", "is the current name")
+	}
+	err := v.err("invalid username")
+	if err != nil {
+		return err
+	}
+	return translate(service.repository.WithinTransaction(ctx, func(repository repositories.RepositoryInterface) error {
+		err := repository.SignIn(ctx, user)
+		if err != nil {
+			return err
+		}
+		return repository.RenameUser(ctx, user.Name, newname, time.Now(), usernameCooldown)
+	}))
+}
+
+func (service *UserService) GetRenamedUsername(ctx context.Context, oldname string) (string, error) {
+	return translated(service.repository.GetRenamedUsername(ctx, oldname, time.Now()))
+}
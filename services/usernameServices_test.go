@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"example/layered-architecture/models"
+	"example/layered-architecture/repositories"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameUser(t *testing.T) {
+	type testCase struct {
+		name                string
+		newname             string
+		returnErrorOnSignIn error
+		expectedSignIns     int
+		expectedRenames     int
+	}
+	testCases := []testCase{{name: "too short",
+		newname:         "ab",
+		expectedSignIns: 0,
+		expectedRenames: 0},
+		{name: "wrong password",
+			newname:             "xyz",
+			returnErrorOnSignIn: errors.New("bad request"),
+			expectedSignIns:     1,
+			expectedRenames:     0},
+		{name: "success",
+			newname:         "xyz",
+			expectedSignIns: 1,
+			expectedRenames: 1}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			user := models.User{Name: "abc", Password: "secret"}
+			mockRepository := repositories.NewMockRepositoryInterface(gomock.NewController(t))
+			expectTransactions(mockRepository)
+			mockRepository.EXPECT().SignIn(gomock.Any(), &user).Return(test.returnErrorOnSignIn).Times(test.expectedSignIns)
+			mockRepository.
+				EXPECT().
+				RenameUser(gomock.Any(), "abc", test.newname, gomock.Any(), usernameCooldown).
+				Return(nil).
+				Times(test.expectedRenames)
+			ms := NewUserService(mockRepository, nil)
+
+			err := ms.RenameUser(context.Background(), &user, test.newname)
+
+			assert.Equal(t, test.expectedRenames == 0, err != nil)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"example/layered-architecture/models"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+const (
+	minUsernameLength    = 3
+	maxUsernameLength    = 15
+	minPasswordLength    = 8
+	maxPasswordLength    = 128
+	maxTweetLength       = 280
+	maxMediaPerTweet     = 4
+	maxListTitleLength   = 25
+	maxListDescription   = 100
+	maxFolderTitleLength = 25
+	// urlLength is what a link counts towards the tweet length, however long
+	// it really is.
+	urlLength = 23
+)
+
+var (
+	usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_]*$`)
+	urlPattern      = regexp.MustCompile(`https?://[^\s]+`)
+)
+
+// rule checks a single input value. It returns what is wrong with the value,
+// or an empty string when the value is fine.
+type rule func(value string) string
+
+// validation collects what is wrong with the fields of one input, at most one
+// problem per field.
+type validation map[string]string
+
+// check runs rules against the value of field until one of them fails.
+func (v validation) check(field string, value string, rules ...rule) {
+	for _, r := range rules {
+		if problem := r(value); problem != "" {
+			v.fail(field, problem)
+			return
+		}
+	}
+}
+
+// fail records a problem with field, unless it already has one.
+func (v validation) fail(field string, problem string) {
+	if _, ok := v[field]; !ok {
+		v[field] = problem
+	}
+}
+
+// err returns the validation error for the collected problems, or nil when
+// there are none.
+func (v validation) err(message string) error {
+	if len(v) == 0 {
+		return nil
+	}
+	return ValidationError(message, v)
+}
+
+func required(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "is required"
+	}
+	return ""
+}
+
+// length limits the number of characters of a value.
+func length(min int, max int) rule {
+	return func(value string) string {
+		n := uniseg.GraphemeClusterCount(value)
+		if n < min || n > max {
+			return fmt.Sprintf("needs between %d and %d characters", min, max)
+		}
+		return ""
+	}
+}
+
+func matches(pattern *regexp.Regexp, description string) rule {
+	return func(value string) string {
+		if !pattern.MatchString(value) {
+			return description
+		}
+		return ""
+	}
+}
+
+func containsLetterAndDigit(value string) string {
+	if strings.IndexFunc(value, unicode.IsLetter) < 0 || strings.IndexFunc(value, unicode.IsDigit) < 0 {
+		return "needs at least one letter and one digit"
+	}
+	return ""
+}
+
+func differentFrom(other string) rule {
+	return func(value string) string {
+		if strings.EqualFold(value, other) {
+			return "must not be the username"
+		}
+		return ""
+	}
+}
+
+func tweetLengthAtMost(max int) rule {
+	return func(value string) string {
+		if TweetLength(value) > max {
+			return fmt.Sprintf("needs at most %d characters", max)
+		}
+		return ""
+	}
+}
+
+var usernameRules = []rule{
+	required,
+	length(minUsernameLength, maxUsernameLength),
+	matches(usernamePattern, "may only contain letters, digits and underscores"),
+}
+
+// passwordRules is the password policy for the account called username.
+func passwordRules(username string) []rule {
+	return []rule{
+		length(minPasswordLength, maxPasswordLength),
+		containsLetterAndDigit,
+		differentFrom(username),
+	}
+}
+
+var tweetRules = []rule{
+	required,
+	tweetLengthAtMost(maxTweetLength),
+}
+
+// TweetLength is the length of a tweet as counted against its limit. It
+// counts what readers see as characters, so an emoji made of several code
+// points counts once, and every link counts as urlLength characters.
+func TweetLength(content string) int {
+	n := 0
+	last := 0
+	for _, link := range urlPattern.FindAllStringIndex(content, -1) {
+		n += uniseg.GraphemeClusterCount(content[last:link[0]]) + urlLength
+		last = link[1]
+	}
+	return n + uniseg.GraphemeClusterCount(content[last:])
+}
+
+func validateUser(user *models.User) error {
+	v := validation{}
+	v.check("name", user.Name, usernameRules...)
+	v.check("password", user.Password, passwordRules(user.Name)...)
+	return v.err("invalid user")
+}
+
+func validateTweet(tweet *models.Tweet) error {
+	v := validation{}
+	v.check("content", tweet.Content, tweetRules...)
+	if len(tweet.MediaIDs) > maxMediaPerTweet {
+		v.fail("mediaids", fmt.Sprintf("allows at most %d media", maxMediaPerTweet))
+	}
+	return v.err("invalid tweet")
+}
+
+func validateDraft(draft *models.Draft, now time.Time) error {
+	v := validation{}
+	//unscheduled drafts may still be empty, they just cannot get too long
+	if draft.PublishAt != nil {
+		v.check("content", draft.Content, tweetRules...)
+		if !draft.PublishAt.After(now) {
+			v.fail("publishat", "must be in the future")
+		}
+	}
+	v.check("content", draft.Content, tweetLengthAtMost(maxTweetLength))
+	return v.err("invalid draft")
+}
+
+func validateFollow(follow *models.Follows) error {
+	v := validation{}
+	v.check("sourceuser", follow.SourceUser, required)
+	v.check("targetuser", follow.TargetUser, required)
+	return v.err("invalid follow")
+}
+
+func validateList(list *models.List) error {
+	v := validation{}
+	v.check("title", list.Title, required, length(1, maxListTitleLength))
+	v.check("description", list.Description, length(0, maxListDescription))
+	return v.err("invalid list")
+}
+
+func validateBookmarkFolder(folder *models.BookmarkFolder) error {
+	v := validation{}
+	v.check("title", folder.Title, required, length(1, maxFolderTitleLength))
+	return v.err("invalid folder")
+}
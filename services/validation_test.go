@@ -0,0 +1,127 @@
+package services
+
+import (
+	"example/layered-architecture/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUser(t *testing.T) {
+	type testCase struct {
+		name           string
+		user           models.User
+		expectedFields map[string]string
+	}
+	testCases := []testCase{{name: "valid",
+		user: models.User{Name: "abc_12", Password: "hunter42"}},
+		{name: "missing",
+			user: models.User{},
+			expectedFields: map[string]string{"name": "is required",
+				"password": "needs between 8 and 128 characters"}},
+		{name: "name too long",
+			user:           models.User{Name: "abcdefghijklmnop", Password: "hunter42"},
+			expectedFields: map[string]string{"name": "needs between 3 and 15 characters"}},
+		{name: "name charset",
+			user:           models.User{Name: "ab-c", Password: "hunter42"},
+			expectedFields: map[string]string{"name": "may only contain letters, digits and underscores"}},
+		{name: "password without digit",
+			user:           models.User{Name: "abc", Password: "hunterhunter"},
+			expectedFields: map[string]string{"password": "needs at least one letter and one digit"}},
+		{name: "password is the name",
+			user:           models.User{Name: "abc12345", Password: "ABC12345"},
+			expectedFields: map[string]string{"password": "must not be the username"}}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateUser(&test.user)
+
+			if test.expectedFields == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.Equal(t, test.expectedFields, err.(*Error).Fields)
+			assert.Equal(t, CodeValidation, err.(*Error).Code)
+		})
+	}
+}
+
+func TestTweetLength(t *testing.T) {
+	type testCase struct {
+		name           string
+		content        string
+		expectedLength int
+	}
+	testCases := []testCase{{name: "ascii",
+		content:        "hello",
+		expectedLength: 5},
+		{name: "combining marks",
+			content:        "café",
+			expectedLength: 4},
+		{name: "emoji sequence",
+			content:        "\U0001F468‍\U0001F469‍\U0001F467 \U0001F44D\U0001F3FD",
+			expectedLength: 3},
+		{name: "urls",
+			content:        "see https://example.com/a/very/long/path?with=query and http://x.io",
+			expectedLength: 4 + 23 + 5 + 23}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedLength, TweetLength(test.content))
+		})
+	}
+}
+
+func TestValidateTweet(t *testing.T) {
+	type testCase struct {
+		name           string
+		tweet          models.Tweet
+		expectedFields map[string]string
+	}
+	testCases := []testCase{{name: "valid",
+		tweet: models.Tweet{Content: strings.Repeat("\U0001F44D", maxTweetLength)}},
+		{name: "long url fits",
+			tweet: models.Tweet{Content: strings.Repeat("a", maxTweetLength-urlLength) + "https://example.com/" + strings.Repeat("b", 100)}},
+		{name: "blank",
+			tweet:          models.Tweet{Content: " \n "},
+			expectedFields: map[string]string{"content": "is required"}},
+		{name: "too long",
+			tweet:          models.Tweet{Content: strings.Repeat("a", maxTweetLength+1)},
+			expectedFields: map[string]string{"content": "needs at most 280 characters"}},
+		{name: "too many media",
+			tweet:          models.Tweet{Content: "abc", MediaIDs: []uint{1, 2, 3, 4, 5}},
+			expectedFields: map[string]string{"mediaids": "allows at most 4 media"}}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateTweet(&test.tweet)
+
+			if test.expectedFields == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.Equal(t, test.expectedFields, err.(*Error).Fields)
+		})
+	}
+}
+
+func TestValidateDraft(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	assert.Nil(t, validateDraft(&models.Draft{}, now))
+	assert.Nil(t, validateDraft(&models.Draft{Content: "abc", PublishAt: &future}, now))
+	err := validateDraft(&models.Draft{PublishAt: &past}, now)
+	assert.Equal(t, map[string]string{"content": "is required", "publishat": "must be in the future"}, err.(*Error).Fields)
+	err = validateDraft(&models.Draft{Content: strings.Repeat("a", maxTweetLength+1)}, now)
+	assert.Equal(t, map[string]string{"content": "needs at most 280 characters"}, err.(*Error).Fields)
+}
+
+func TestErrorMessageListsFields(t *testing.T) {
+	err := ValidationError("invalid user", map[string]string{"password": "is required", "name": "is required"})
+
+	assert.Equal(t, "invalid user: name is required, password is required", err.Error())
+}
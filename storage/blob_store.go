@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound is returned by a BlobStore when no blob exists for a key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps the raw bytes of uploaded files (images, thumbnails) out of
+// the database. Keys are opaque, slash separated paths chosen by the caller.
+type BlobStore interface {
+	Put(key string, data io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir())
+	assert.Nil(t, err)
+
+	err = store.Put("media/abc", strings.NewReader("hello"))
+	assert.Nil(t, err)
+
+	blob, err := store.Get("media/abc")
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, "hello", string(data))
+
+	assert.Nil(t, store.Delete("media/abc"))
+	_, err = store.Get("media/abc")
+	assert.Equal(t, ErrBlobNotFound, err)
+
+	err = store.Put("../outside", strings.NewReader("x"))
+	assert.NotNil(t, err)
+}